@@ -0,0 +1,11 @@
+# Describe the credential used by the provider and warn during plan when the API certificate has less than two weeks
+# left before it expires.
+
+data "f5xc_api_credential_info" "current" {}
+
+check "api_certificate_expiry" {
+  assert {
+    condition     = data.f5xc_api_credential_info.current.not_after == null || timecmp(data.f5xc_api_credential_info.current.not_after, timeadd(plantimestamp(), "336h")) > 0
+    error_message = format("The F5XC API certificate for tenant %s expires at %s; generate a new certificate.", data.f5xc_api_credential_info.current.tenant, data.f5xc_api_credential_info.current.not_after)
+  }
+}
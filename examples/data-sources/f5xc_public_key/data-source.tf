@@ -0,0 +1,11 @@
+# Retrieve the tenant's blindfold public key and fail the plan if the key has been rotated since the sealed values were
+# generated.
+
+data "f5xc_public_key" "current" {}
+
+check "public_key_version" {
+  assert {
+    condition     = data.f5xc_public_key.current.key_version == var.expected_key_version
+    error_message = format("Tenant public key is at version %d; blindfolded secrets should be regenerated.", data.f5xc_public_key.current.key_version)
+  }
+}
@@ -0,0 +1,23 @@
+# Verify that a secret policy allows the intended client before blindfolding a secret with it.
+
+data "f5xc_secret_policy_document" "policy" {
+  name      = "my-secret-policy"
+  namespace = "shared"
+}
+
+resource "f5xc_blindfold" "creds" {
+  plaintext = base64encode(var.origin_password)
+  policy_document = {
+    name      = data.f5xc_secret_policy_document.policy.name
+    namespace = data.f5xc_secret_policy_document.policy.namespace
+  }
+
+  lifecycle {
+    precondition {
+      condition = anytrue([
+        for rule in data.f5xc_secret_policy_document.policy.rules : rule.action == "ALLOW" && rule.client_name == var.expected_client_name
+      ])
+      error_message = "The secret policy does not allow the expected client to decrypt the secret."
+    }
+  }
+}
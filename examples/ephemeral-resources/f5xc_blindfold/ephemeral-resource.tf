@@ -0,0 +1,21 @@
+# Blindfold a secret received through an ephemeral input variable. Neither the secret nor the sealed value is written to
+# Terraform plan or state, so the sealed value can only be used where ephemeral values are accepted, such as write-only
+# arguments or provider configuration blocks.
+
+variable "origin_password" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+ephemeral "f5xc_blindfold" "origin_password" {
+  plaintext = base64encode(var.origin_password)
+  policy_document = {
+    name      = "ves-io-allow-volterra"
+    namespace = "shared"
+  }
+}
+
+locals {
+  origin_password_location = format("string:///%s", ephemeral.f5xc_blindfold.origin_password.sealed)
+}
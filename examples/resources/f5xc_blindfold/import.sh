@@ -0,0 +1,3 @@
+# Import an existing sealed value using the namespace and name of the secret policy document that was used to blindfold
+# it, and either the base64 encoded sealed value or the path to a file containing the sealed value.
+terraform import f5xc_blindfold.creds shared/ves-io-allow-volterra:/path/to/sealed.txt
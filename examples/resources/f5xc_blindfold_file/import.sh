@@ -0,0 +1,4 @@
+# Import an existing sealed value using the namespace and name of the secret policy document that was used to blindfold
+# it, and either the base64 encoded sealed value or the path to a file containing the sealed value. The path to the
+# plaintext file, or its source, is taken from the configuration.
+terraform import f5xc_blindfold_file.creds shared/ves-io-allow-volterra:/path/to/sealed.txt
@@ -15,7 +15,7 @@ resource "volterra_cloud_credential" "gcp" {
   gcp_cred_file {
     credential_file {
       blindfold_secret_info {
-        location = format("string:///%s", f5xc_blindfold_file.creds.sealed)
+        location = f5xc_blindfold_file.creds.location
       }
     }
   }
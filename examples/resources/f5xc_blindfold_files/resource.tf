@@ -0,0 +1,18 @@
+# Blindfold every per-site JSON credential in a directory tree; adding, removing, or changing a file will only affect
+# the sealed value of that file.
+
+resource "f5xc_blindfold_files" "sites" {
+  directory = "/path/to/sites"
+  include   = ["**/*.json"]
+  exclude   = ["**/*.example.json"]
+  policy_document = {
+    name      = "ves-io-allow-volterra"
+    namespace = "shared"
+  }
+}
+
+output "site_credential_locations" {
+  value = {
+    for file, sealed in f5xc_blindfold_files.sites.sealed : file => format("string:///%s", sealed)
+  }
+}
@@ -0,0 +1,17 @@
+# Blindfold a set of origin passwords with a single resource; only the entries that change will be blindfolded again.
+
+resource "f5xc_blindfold_map" "origins" {
+  plaintext = {
+    for name, password in var.origin_passwords : name => base64encode(password)
+  }
+  policy_document = {
+    name      = "ves-io-allow-volterra"
+    namespace = "shared"
+  }
+}
+
+output "origin_password_locations" {
+  value = {
+    for name, sealed in f5xc_blindfold_map.origins.sealed : name => format("string:///%s", sealed)
+  }
+}
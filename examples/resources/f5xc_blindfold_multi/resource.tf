@@ -0,0 +1,19 @@
+# Blindfold a single secret for use by two different secret policies; the public key is fetched only once.
+
+resource "f5xc_blindfold_multi" "secret" {
+  plaintext = base64encode(var.secret)
+  policy_documents = [
+    {
+      name      = "ves-io-allow-volterra"
+      namespace = "shared"
+    },
+    {
+      name      = "my-policy"
+      namespace = "shared"
+    },
+  ]
+}
+
+output "volterra_location" {
+  value = format("string:///%s", f5xc_blindfold_multi.secret.sealed["shared/ves-io-allow-volterra"])
+}
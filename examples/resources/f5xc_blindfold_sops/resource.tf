@@ -0,0 +1,25 @@
+# Blindfold a Google service account key stored in a SOPS encrypted YAML file, without writing the decrypted key to
+# disk, and register as a Cloud Credential to provision GCP VPC sites.
+
+resource "f5xc_blindfold_sops" "creds" {
+  path         = "${path.module}/secrets.enc.yaml"
+  extract      = ".gcp.service_account"
+  age_key_file = pathexpand("~/.config/sops/age/keys.txt")
+  policy_document = {
+    name      = "ves-io-allow-volterra"
+    namespace = "shared"
+  }
+}
+
+resource "volterra_cloud_credential" "gcp" {
+  name        = "gcp"
+  namespace   = "system"
+  description = "GCP SA"
+  gcp_cred_file {
+    credential_file {
+      blindfold_secret_info {
+        location = f5xc_blindfold_sops.creds.location
+      }
+    }
+  }
+}
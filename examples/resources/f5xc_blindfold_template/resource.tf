@@ -0,0 +1,19 @@
+# Blindfold a kubeconfig that embeds a service account token, without the rendered kubeconfig or the token being
+# stored in Terraform state.
+
+resource "f5xc_blindfold_template" "kubeconfig" {
+  template = file("${path.module}/kubeconfig.tmpl")
+  vars = {
+    server = var.kubernetes_server
+    ca     = var.kubernetes_ca
+    token  = var.kubernetes_token
+  }
+  policy_document = {
+    name      = "ves-io-allow-volterra"
+    namespace = "shared"
+  }
+}
+
+output "kubeconfig_location" {
+  value = f5xc_blindfold_template.kubeconfig.location
+}
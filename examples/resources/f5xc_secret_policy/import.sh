@@ -0,0 +1,3 @@
+# Import an existing secret policy using its namespace and name, and whether the policy is locked or unlocked. The lock
+# state is not reported by F5 Distributed Cloud, so it must be given with the identifier.
+terraform import f5xc_secret_policy.policy shared/my-policy:locked
@@ -0,0 +1,30 @@
+# Create a tightly scoped secret policy and use it to blindfold a secret in the same apply.
+
+resource "f5xc_secret_policy_rule" "sites" {
+  name      = "allow-prod-sites"
+  namespace = "shared"
+  action    = "ALLOW"
+  client_selector = {
+    expressions = ["env in (prod)"]
+  }
+}
+
+resource "f5xc_secret_policy" "prod" {
+  name      = "prod-sites"
+  namespace = "shared"
+  rules = [
+    {
+      name      = f5xc_secret_policy_rule.sites.name
+      namespace = f5xc_secret_policy_rule.sites.namespace
+    },
+  ]
+  locked = true
+}
+
+resource "f5xc_blindfold" "origin_password" {
+  plaintext = base64encode(var.origin_password)
+  policy_document = {
+    name      = f5xc_secret_policy.prod.name
+    namespace = f5xc_secret_policy.prod.namespace
+  }
+}
@@ -0,0 +1,11 @@
+# Allow any client whose name matches the regular expression to decrypt secrets governed by a policy that references
+# this rule.
+
+resource "f5xc_secret_policy_rule" "sites" {
+  name      = "allow-prod-sites"
+  namespace = "shared"
+  action    = "ALLOW"
+  client_name_matcher = {
+    regex_values = ["^prod-site-[0-9]+$"]
+  }
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	// errNotFound is returned when the F5XC API reports that the requested object does not exist.
+	errNotFound = errors.New("object not found")
+	// errUnexpectedStatus is returned when the F5XC API responds with an unexpected HTTP status code.
+	errUnexpectedStatus = errors.New("unexpected HTTP status")
+	// errUnauthorized is returned, wrapped with errUnexpectedStatus, when the F5XC API rejects the credentials of the
+	// request.
+	errUnauthorized = errors.New("credentials rejected")
+)
+
+// The maximum number of bytes of an error response body that will be included in an error message.
+const maxErrorBodyLength = 1024
+
+// objectMetadataJSON mirrors the metadata that is common to all F5XC configuration objects.
+type objectMetadataJSON struct {
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace"`
+	Description string `json:"description,omitempty"`
+}
+
+// objectRefJSON mirrors the reference to another F5XC configuration object.
+type objectRefJSON struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Tenant    string `json:"tenant,omitempty"`
+}
+
+// blindfoldSecretInfoJSON mirrors the F5XC blindfold_secret_info object that references a blindfolded secret. Empty
+// providers are sent as empty strings, which F5XC treats as its defaults.
+type blindfoldSecretInfoJSON struct {
+	DecryptionProvider string `json:"decryption_provider"`
+	Location           string `json:"location"`
+	StoreProvider      string `json:"store_provider"`
+}
+
+// Returns the F5XC location of a sealed value, and the JSON representation of a blindfold_secret_info object that
+// references it through the decryption and store providers.
+func blindfoldSecretInfo(sealed, decryptionProvider, storeProvider string) (string, string, error) {
+	location := "string:///" + sealed
+	data, err := json.Marshal(blindfoldSecretInfoJSON{
+		DecryptionProvider: decryptionProvider,
+		Location:           location,
+		StoreProvider:      storeProvider,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encode blindfold_secret_info: %w", err)
+	}
+	return location, string(data), nil
+}
+
+// Returns the F5XC secret management API URL for the collection of objects of kind in namespace, or for a single named
+// object if any additional path elements are provided.
+func secretManagementURL(apiURL, namespace, kind string, elem ...string) (string, error) {
+	endpoint, err := url.JoinPath(apiURL, append([]string{"secret_management", "namespaces", namespace, kind}, elem...)...)
+	if err != nil {
+		return "", fmt.Errorf("failed to build API URL: %w", err)
+	}
+	return endpoint, nil
+}
+
+// Makes a request to the F5XC API, sending the JSON encoding of payload if it is not nil, and decodes the JSON response
+// into result if it is not nil. An error wrapping errNotFound is returned if the API responds with a 404 status.
+func doAPIRequest(ctx context.Context, client *http.Client, method, endpoint string, payload, result any) error {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to encode request payload: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			tflog.Warn(ctx, "Failed to close API response body", map[string]any{"error": err.Error()})
+		}
+	}()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("%w: %s %s", errNotFound, method, endpoint)
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("%w: %w: %s %s returned %s", errUnexpectedStatus, errUnauthorized, method, endpoint, resp.Status)
+	case resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices:
+		message, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLength))
+		if err != nil {
+			message = []byte(err.Error())
+		}
+		return fmt.Errorf("%w: %s %s returned %s: %s", errUnexpectedStatus, method, endpoint, resp.Status, string(message))
+	case result == nil:
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
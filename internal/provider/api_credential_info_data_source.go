@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &apiCredentialInfoDataSource{}
+	_ datasource.DataSourceWithConfigure = &apiCredentialInfoDataSource{}
+)
+
+type apiCredentialInfoDataSource struct {
+	url         string
+	authMethod  string
+	certificate *x509.Certificate
+}
+
+type apiCredentialInfoDataSourceModel struct {
+	Method      types.String `tfsdk:"method"`
+	Subject     types.String `tfsdk:"subject"`
+	Tenant      types.String `tfsdk:"tenant"`
+	Serial      types.String `tfsdk:"serial"`
+	NotBefore   types.String `tfsdk:"not_before"`
+	NotAfter    types.String `tfsdk:"not_after"`
+	Fingerprint types.String `tfsdk:"fingerprint"`
+}
+
+// NewAPICredentialInfoDataSource creates a new API credential info Terraform data source and returns a pointer to it.
+func NewAPICredentialInfoDataSource() datasource.DataSource {
+	return &apiCredentialInfoDataSource{}
+}
+
+// Implement the Metadata function for DataSource interface.
+func (d *apiCredentialInfoDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_credential_info"
+}
+
+// Implement the Schema function for DataSource interface. The API credential info data source does not accept any
+// inputs; all attributes are populated from the credential the provider uses to authenticate to F5XC.
+func (d *apiCredentialInfoDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Describes the credential that the provider uses to authenticate to F5 Distributed Cloud, " +
+			"so that `check` blocks can assert on the certificate expiry or tenant. The certificate attributes are " +
+			"null when the provider authenticates with an API token.",
+		Attributes: map[string]schema.Attribute{
+			"method": schema.StringAttribute{
+				Description: "The authentication method used by the provider; one of \"" + authMethodP12Content +
+					"\", \"" + authMethodP12File + "\", \"" + authMethodCertKey + "\", or \"" + authMethodToken + "\".",
+				Computed: true,
+			},
+			"subject": schema.StringAttribute{
+				Description: "The distinguished name of the certificate subject.",
+				Computed:    true,
+			},
+			"tenant": schema.StringAttribute{
+				Description: "The tenant named in the certificate subject, or the tenant of the provider URL if the " +
+					"subject does not name one.",
+				Computed: true,
+			},
+			"serial": schema.StringAttribute{
+				Description: "The hex encoded serial number of the certificate.",
+				Computed:    true,
+			},
+			"not_before": schema.StringAttribute{
+				Description: "The RFC3339 timestamp from which the certificate is valid.",
+				Computed:    true,
+			},
+			"not_after": schema.StringAttribute{
+				Description: "The RFC3339 timestamp at which the certificate expires.",
+				Computed:    true,
+			},
+			"fingerprint": schema.StringAttribute{
+				Description: "The hex encoded SHA-256 fingerprint of the certificate.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Implement the Configure function for DataSource interface.
+func (d *apiCredentialInfoDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*f5XCConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *f5XCConfig, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.url = cfg.url
+	d.authMethod = cfg.authMethod
+	d.certificate = cfg.certificate
+}
+
+// Implement the Read function for DataSource interface.
+func (d *apiCredentialInfoDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) { //nolint:gocritic // Provider interface passes ReadRequest by value.
+	tflog.Info(ctx, "Reading API credential info data source")
+
+	model := apiCredentialInfoDataSourceModel{
+		Method:      types.StringValue(d.authMethod),
+		Subject:     types.StringNull(),
+		Tenant:      types.StringNull(),
+		Serial:      types.StringNull(),
+		NotBefore:   types.StringNull(),
+		NotAfter:    types.StringNull(),
+		Fingerprint: types.StringNull(),
+	}
+	tenant := urlTenant(d.url)
+	if cert := d.certificate; cert != nil {
+		if certTenant := certificateTenant(cert); certTenant != "" {
+			tenant = certTenant
+		}
+		fingerprint := sha256.Sum256(cert.Raw)
+		model.Subject = types.StringValue(cert.Subject.String())
+		model.Serial = types.StringValue(cert.SerialNumber.Text(16))
+		model.NotBefore = types.StringValue(cert.NotBefore.UTC().Format(time.RFC3339))
+		model.NotAfter = types.StringValue(cert.NotAfter.UTC().Format(time.RFC3339))
+		model.Fingerprint = types.StringValue(hex.EncodeToString(fingerprint[:]))
+	}
+	if tenant != "" {
+		model.Tenant = types.StringValue(tenant)
+	}
+	diags := resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,25 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAPICredentialInfoDataSource(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "f5xc_api_credential_info" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.f5xc_api_credential_info.test", "method"),
+					resource.TestCheckResourceAttrSet("data.f5xc_api_credential_info.test", "tenant"),
+				),
+			},
+		},
+	})
+}
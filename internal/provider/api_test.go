@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestBlindfoldSecretInfo(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name               string
+		sealed             string
+		decryptionProvider string
+		storeProvider      string
+		expectedLocation   string
+		expectedJSON       string
+	}{
+		{
+			name:             "sealed",
+			sealed:           "c2VhbGVk",
+			expectedLocation: "string:///c2VhbGVk",
+			expectedJSON:     `{"decryption_provider":"","location":"string:///c2VhbGVk","store_provider":""}`,
+		},
+		{
+			name:             "padded",
+			sealed:           "c2VhbGVkIGRhdGE+Lw==",
+			expectedLocation: "string:///c2VhbGVkIGRhdGE+Lw==",
+			expectedJSON:     `{"decryption_provider":"","location":"string:///c2VhbGVkIGRhdGE+Lw==","store_provider":""}`,
+		},
+		{
+			name:               "providers",
+			sealed:             "c2VhbGVk",
+			decryptionProvider: "decryptor",
+			storeProvider:      "store",
+			expectedLocation:   "string:///c2VhbGVk",
+			expectedJSON:       `{"decryption_provider":"decryptor","location":"string:///c2VhbGVk","store_provider":"store"}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			location, secretInfo, err := blindfoldSecretInfo(test.sealed, test.decryptionProvider, test.storeProvider)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if location != test.expectedLocation {
+				t.Errorf("expected location %q, got %q", test.expectedLocation, location)
+			}
+			if secretInfo != test.expectedJSON {
+				t.Errorf("expected secret info %s, got %s", test.expectedJSON, secretInfo)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/memes/f5xc"
+)
+
+// The F5XC API authentication methods, in order of precedence.
+const (
+	authMethodP12Content = "PKCS#12 content"
+	authMethodP12File    = "PKCS#12 file"
+	authMethodCertKey    = "x509 certificate and key"
+	authMethodToken      = "API token"
+)
+
+// providerValue is a provider configuration value and a description of where it was set.
+type providerValue struct {
+	value      string
+	source     string
+	fromConfig bool
+}
+
+// Returns the provider value from the config attribute, falling back to the environment variable if the attribute is
+// null.
+func newProviderValue(config types.String, attribute, env string) providerValue {
+	if !config.IsNull() {
+		return providerValue{value: config.ValueString(), source: attribute + " attribute", fromConfig: true}
+	}
+	if value := os.Getenv(env); value != "" {
+		return providerValue{value: value, source: env + " environment variable"}
+	}
+	return providerValue{}
+}
+
+// Returns true if the value is not empty.
+func (v providerValue) isSet() bool {
+	return v.value != ""
+}
+
+// f5XCAuth holds the F5XC API authentication values resolved from provider config and environment variables.
+type f5XCAuth struct {
+	token       providerValue
+	p12Content  providerValue
+	p12File     providerValue
+	p12Password providerValue
+	cert        providerValue
+	key         providerValue
+	// The authentication method chosen by options, and a description of where its values were set.
+	method  string
+	sources string
+}
+
+// Returns the F5XC API authentication values of the provider config, with environment variables used for any
+// attributes that are null.
+func newF5XCAuth(config *f5XCProviderModel) *f5XCAuth {
+	return &f5XCAuth{
+		token:       newProviderValue(config.Token, "api_token", "VOLTERRA_TOKEN"),
+		p12Content:  newProviderValue(config.PKCS12Content, "api_p12_content", "VES_P12_CONTENT"),
+		p12File:     newProviderValue(config.PKCS12File, "api_p12_file", "VOLT_API_P12_FILE"),
+		p12Password: newProviderValue(config.PKCS12Password, "api_p12_password", "VES_P12_PASSWORD"),
+		cert:        newProviderValue(config.Cert, "api_cert", "VOLT_API_CERT"),
+		key:         newProviderValue(config.Key, "api_key", "VOLT_API_KEY"),
+	}
+}
+
+// authCandidate is an authentication method and the values that identify it.
+type authCandidate struct {
+	method string
+	values []providerValue
+}
+
+// Returns true if any of the values of the candidate are set, optionally limited to values set in config.
+func (c *authCandidate) isSet(configOnly bool) bool {
+	for _, value := range c.values {
+		if value.isSet() && (value.fromConfig || !configOnly) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns a description of where the values of the candidate were set.
+func (c *authCandidate) sources() string {
+	sources := make([]string, 0, len(c.values))
+	for _, value := range c.values {
+		if value.isSet() {
+			sources = append(sources, value.source)
+		}
+	}
+	return strings.Join(sources, " and ")
+}
+
+// Returns the authentication method candidates in order of precedence.
+func (a *f5XCAuth) candidates() []authCandidate {
+	return []authCandidate{
+		{method: authMethodP12Content, values: []providerValue{a.p12Content}},
+		{method: authMethodP12File, values: []providerValue{a.p12File}},
+		{method: authMethodCertKey, values: []providerValue{a.cert, a.key}},
+		{method: authMethodToken, values: []providerValue{a.token}},
+	}
+}
+
+// Selects a single authentication method, preferring methods set in config over those set in environment variables,
+// and returns the options to create an F5XC API client with it. A single warning diagnostic reports the method that was
+// chosen and where its values were set, along with any other methods that were ignored. Inline PEM certificate and key
+// content cannot be passed as an option and is reported through inlineCertKeyPair instead.
+func (a *f5XCAuth) options(ctx context.Context) (options []f5xc.Option, inlineCertKeyPair bool, diags diag.Diagnostics) {
+	candidates := a.candidates()
+	chosen := -1
+	for _, configOnly := range []bool{true, false} {
+		for i := range candidates {
+			if chosen == -1 && candidates[i].isSet(configOnly) {
+				chosen = i
+			}
+		}
+	}
+	if chosen == -1 {
+		diags.AddError(
+			"Missing F5XC API authentication",
+			"The provider cannot create the F5XC API client as no authentication method is configured. Set one of "+
+				"api_p12_content, api_p12_file, api_cert and api_key, or api_token in the configuration, or use the "+
+				"equivalent environment variable.",
+		)
+		return nil, false, diags
+	}
+	candidate := candidates[chosen]
+	sources := candidate.sources()
+	switch candidate.method {
+	case authMethodP12Content, authMethodP12File:
+		if !a.p12Password.isSet() {
+			diags.AddError(
+				"Missing F5XC API PKCS#12 passphrase",
+				"The provider cannot create the F5XC API client as the "+candidate.method+" set in "+sources+" has no "+
+					"passphrase. Set the api_p12_password value in the configuration or use the VES_P12_PASSWORD "+
+					"environment variable.",
+			)
+			return nil, false, diags
+		}
+		sources += " with passphrase from " + a.p12Password.source
+		if candidate.method == authMethodP12Content {
+			options = append(options, f5xc.WithP12CertificateContent(a.p12Content.value, a.p12Password.value))
+		} else {
+			options = append(options, f5xc.WithP12CertificateFile(a.p12File.value, a.p12Password.value))
+		}
+	case authMethodCertKey:
+		if !a.cert.isSet() || !a.key.isSet() {
+			diags.AddError(
+				"Incomplete F5XC API x509 certificate and key",
+				"The provider cannot create the F5XC API client as only one of the x509 certificate and key is set, "+
+					"in "+sources+". Set both api_cert and api_key values in the configuration or use the "+
+					"VOLT_API_CERT and VOLT_API_KEY environment variables.",
+			)
+			return nil, false, diags
+		}
+		inlineCertKeyPair = isInlinePEM(a.cert.value) || isInlinePEM(a.key.value)
+		if !inlineCertKeyPair {
+			options = append(options, f5xc.WithCertKeyPair(a.cert.value, a.key.value))
+		}
+	case authMethodToken:
+		options = append(options, f5xc.WithAuthToken(a.token.value))
+	}
+
+	tflog.Info(ctx, "Selected F5XC API authentication method", map[string]any{"method": candidate.method, "sources": sources})
+	var ignored []string
+	for i := range candidates {
+		if i != chosen && candidates[i].isSet(false) {
+			ignored = append(ignored, fmt.Sprintf(" The %s from %s is ignored.", candidates[i].method, candidates[i].sources()))
+		}
+	}
+	diags.AddWarning(
+		"F5XC API authentication method",
+		fmt.Sprintf("The F5XC API client will authenticate with the %s from %s.", candidate.method, sources)+
+			strings.Join(ignored, ""),
+	)
+	a.method = candidate.method
+	a.sources = sources
+	return options, inlineCertKeyPair, diags
+}
+
+var _ provider.ConfigValidator = f5XCAuthConfigValidator{}
+
+// f5XCAuthConfigValidator rejects provider configurations that set more than one authentication method, or that set
+// only part of an authentication method when the remainder is not available from environment variables.
+type f5XCAuthConfigValidator struct{}
+
+// Implement the Description function for ConfigValidator interface.
+func (v f5XCAuthConfigValidator) Description(_ context.Context) string {
+	return "At most one of api_p12_content, api_p12_file, api_cert and api_key, or api_token can be configured, and " +
+		"each must be configured completely."
+}
+
+// Implement the MarkdownDescription function for ConfigValidator interface.
+func (v f5XCAuthConfigValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// Implement the ValidateProvider function for ConfigValidator interface.
+func (v f5XCAuthConfigValidator) ValidateProvider(ctx context.Context, req provider.ValidateConfigRequest, resp *provider.ValidateConfigResponse) { //nolint:gocritic // ConfigValidator interface passes ValidateConfigRequest by value.
+	attributes := []string{"api_token", "api_p12_content", "api_p12_file", "api_p12_password", "api_cert", "api_key"}
+	values := make(map[string]types.String, len(attributes))
+	for _, attribute := range attributes {
+		var value types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attribute), &value)...)
+		values[attribute] = value
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	isSet := func(attribute string) bool {
+		return !values[attribute].IsNull()
+	}
+	isAvailable := func(attribute, env string) bool {
+		return isSet(attribute) || os.Getenv(env) != ""
+	}
+
+	var methods []string
+	if isSet("api_p12_content") {
+		methods = append(methods, "api_p12_content")
+	}
+	if isSet("api_p12_file") {
+		methods = append(methods, "api_p12_file")
+	}
+	if isSet("api_cert") || isSet("api_key") {
+		methods = append(methods, "api_cert and api_key")
+	}
+	if isSet("api_token") {
+		methods = append(methods, "api_token")
+	}
+	if len(methods) > 1 {
+		resp.Diagnostics.AddError(
+			"Conflicting F5XC API authentication",
+			"Only one F5XC API authentication method can be configured, got: "+strings.Join(methods, ", ")+".",
+		)
+	}
+
+	if (isSet("api_p12_content") || isSet("api_p12_file")) && !isAvailable("api_p12_password", "VES_P12_PASSWORD") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_p12_password"),
+			"Missing F5XC API PKCS#12 passphrase",
+			"A passphrase is required to unlock the PKCS#12 certificate. Set the api_p12_password value in the "+
+				"configuration or use the VES_P12_PASSWORD environment variable.",
+		)
+	}
+	if isSet("api_cert") && !isAvailable("api_key", "VOLT_API_KEY") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_key"),
+			"Missing F5XC API x509 key",
+			"The api_cert value requires an x509 key. Set the api_key value in the configuration or use the "+
+				"VOLT_API_KEY environment variable.",
+		)
+	}
+	if isSet("api_key") && !isAvailable("api_cert", "VOLT_API_CERT") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_cert"),
+			"Missing F5XC API x509 certificate",
+			"The api_key value requires an x509 certificate. Set the api_cert value in the configuration or use the "+
+				"VOLT_API_CERT environment variable.",
+		)
+	}
+}
@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestF5XCAuthOptions(t *testing.T) {
+	t.Parallel()
+	token := providerValue{value: "token", source: "api_token attribute", fromConfig: true}
+	envToken := providerValue{value: "token", source: "VOLTERRA_TOKEN environment variable"}
+	p12File := providerValue{value: "/tmp/test.p12", source: "VOLT_API_P12_FILE environment variable"}
+	p12Password := providerValue{value: "passphrase", source: "VES_P12_PASSWORD environment variable"}
+	tests := []struct {
+		name            string
+		auth            f5XCAuth
+		expectedMethod  string
+		expectedError   bool
+		expectedChosen  string
+		expectedIgnored []string
+	}{
+		{
+			name:           "token",
+			auth:           f5XCAuth{token: token},
+			expectedMethod: authMethodToken,
+			expectedChosen: "authenticate with the API token from api_token attribute.",
+		},
+		{
+			name:           "p12 file",
+			auth:           f5XCAuth{p12File: p12File, p12Password: p12Password},
+			expectedMethod: authMethodP12File,
+			expectedChosen: "authenticate with the PKCS#12 file from VOLT_API_P12_FILE environment variable with " +
+				"passphrase from VES_P12_PASSWORD environment variable.",
+		},
+		{
+			name:            "config token ignores environment p12 file",
+			auth:            f5XCAuth{token: token, p12File: p12File, p12Password: p12Password},
+			expectedMethod:  authMethodToken,
+			expectedChosen:  "authenticate with the API token from api_token attribute.",
+			expectedIgnored: []string{"The PKCS#12 file from VOLT_API_P12_FILE environment variable is ignored."},
+		},
+		{
+			name:            "environment p12 file ignores environment token",
+			auth:            f5XCAuth{token: envToken, p12File: p12File, p12Password: p12Password},
+			expectedMethod:  authMethodP12File,
+			expectedChosen:  "authenticate with the PKCS#12 file from VOLT_API_P12_FILE environment variable",
+			expectedIgnored: []string{"The API token from VOLTERRA_TOKEN environment variable is ignored."},
+		},
+		{
+			name:          "p12 file without passphrase",
+			auth:          f5XCAuth{p12File: p12File},
+			expectedError: true,
+		},
+		{
+			name:          "missing",
+			auth:          f5XCAuth{},
+			expectedError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			_, _, diags := test.auth.options(context.Background())
+			if diags.HasError() != test.expectedError {
+				t.Fatalf("expected error to be %t, got diagnostics: %v", test.expectedError, diags)
+			}
+			if test.expectedError {
+				return
+			}
+			if test.auth.method != test.expectedMethod {
+				t.Errorf("expected method %q, got %q", test.expectedMethod, test.auth.method)
+			}
+			warnings := diags.Warnings()
+			if len(warnings) != 1 {
+				t.Fatalf("expected a single warning, got %v", warnings)
+			}
+			if !strings.Contains(warnings[0].Detail(), test.expectedChosen) {
+				t.Errorf("expected warning to contain %q, got %q", test.expectedChosen, warnings[0].Detail())
+			}
+			if ignored := strings.Count(warnings[0].Detail(), " is ignored."); ignored != len(test.expectedIgnored) {
+				t.Errorf("expected %d ignored methods, got %q", len(test.expectedIgnored), warnings[0].Detail())
+			}
+			for _, ignored := range test.expectedIgnored {
+				if !strings.Contains(warnings[0].Detail(), ignored) {
+					t.Errorf("expected warning to contain %q, got %q", ignored, warnings[0].Detail())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/memes/f5xc/blindfold"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &blindfoldEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &blindfoldEphemeralResource{}
+)
+
+type blindfoldEphemeralResource struct {
+	timeout time.Duration
+	cache   *blindfoldCache
+}
+
+type blindfoldEphemeralResourceModel struct {
+	Sealed         types.String        `tfsdk:"sealed"`
+	Plaintext      types.String        `tfsdk:"plaintext"`
+	PolicyDocument policyDocumentModel `tfsdk:"policy_document"`
+	Vesctl         types.String        `tfsdk:"vesctl"`
+}
+
+// NewBlindfoldEphemeralResource creates a new blindfold Terraform ephemeral resource and returns a pointer to it.
+func NewBlindfoldEphemeralResource() ephemeral.EphemeralResource {
+	return &blindfoldEphemeralResource{}
+}
+
+// Implement the Metadata function for EphemeralResource interface.
+func (r *blindfoldEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_blindfold"
+}
+
+// Implement the Schema function for EphemeralResource interface. Ephemeral blindfold resources accept the same
+// plaintext and policy document reference as the managed blindfold resource, but neither the plaintext nor the sealed
+// result are persisted to Terraform plan or state.
+func (r *blindfoldEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Generates a blindfolded secret from a base64 encoded source string, without storing the " +
+			"source or sealed values in Terraform plan or state.\n\n" +
+			"NOTE: Ephemeral resources require Terraform 1.10 or later, and a new sealed value is generated every time " +
+			"the ephemeral resource is opened.",
+		Attributes: map[string]schema.Attribute{
+			"sealed": schema.StringAttribute{
+				Description: "The base64 encoded, sealed data resulting from a blindfold.",
+				Computed:    true,
+			},
+			"plaintext": schema.StringAttribute{
+				Description: "The base64 encoded plaintext data that will be blindfolded.",
+				Required:    true,
+				Sensitive:   true,
+			},
+			"policy_document": schema.SingleNestedAttribute{
+				Required: true,
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Description: "The name of the F5XC PolicyDocument to use for blindfold.",
+						Required:    true,
+					},
+					"namespace": schema.StringAttribute{
+						Description: "The namespace of the F5XC PolicyDocument to use for blindfold.",
+						Required:    true,
+					},
+				},
+			},
+			"vesctl": schema.StringAttribute{
+				MarkdownDescription: "The path to `vesctl` binary to use for blindfolding. If " +
+					"unspecified, the first vesctl binary found in PATH will be used",
+				Optional: true,
+			},
+		},
+	}
+}
+
+// Implement the Configure function for EphemeralResource interface.
+func (r *blindfoldEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*f5XCConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *f5XCConfig, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.timeout = cfg.timeout
+	r.cache = cfg.cache
+}
+
+// Implement the Open function for EphemeralResource interface. The plaintext is blindfolded every time the ephemeral
+// resource is opened and the sealed value is returned to Terraform without being persisted.
+func (r *blindfoldEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) { //nolint:gocritic // Provider interface passes OpenRequest by value.
+	tflog.Info(ctx, "Opening blindfold ephemeral resource")
+	var model blindfoldEphemeralResourceModel
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "policy_doc_name", model.PolicyDocument.Name.ValueString())
+	ctx = tflog.SetField(ctx, "policy_doc_namespace", model.PolicyDocument.Namespace.ValueString())
+	ctx = tflog.SetField(ctx, "vesctl", model.Vesctl.ValueString())
+
+	tflog.Debug(ctx, "Decoding plaintext value from base64")
+	plaintext, err := base64.StdEncoding.DecodeString(model.Plaintext.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error decoding Base64 plaintext",
+			"Failed to decode base64 plaintext to byte array, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Fetching Public Key")
+	pubKey, err := r.cache.publicKey(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving PublicKey",
+			"Could not retrieve PublicKey, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Fetching Secret Policy Document")
+	policyDoc, err := r.cache.secretPolicyDocument(ctx, model.PolicyDocument.Name.ValueString(), model.PolicyDocument.Namespace.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving SecretPolicyDocument",
+			"Could not retrieve SecretPolicyDocument, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Executing blindfold")
+	clientCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	sealed, err := blindfold.Seal(clientCtx, model.Vesctl.ValueString(), plaintext, pubKey, policyDoc)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error blindfolding data",
+			"Failed to blindfold data, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	model.Sealed = types.StringValue(string(sealed))
+
+	diags = resp.Result.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,43 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccBlindfoldEphemeralResource(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+ephemeral "f5xc_blindfold" "test" {
+	plaintext = "VGhpcyBpcyBhIHRlc3Q="
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+}
+
+provider "echo" {
+	data = ephemeral.f5xc_blindfold.test.sealed
+}
+
+resource "echo" "test" {}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data"), knownvalue.NotNull()),
+				},
+			},
+		},
+	})
+}
@@ -2,40 +2,60 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
-	"net/http"
+	"io"
 	"os"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/memes/f5xc"
 	"github.com/memes/f5xc/blindfold"
 )
 
 var (
-	_ resource.Resource              = &blindfoldFileResource{}
-	_ resource.ResourceWithConfigure = &blindfoldFileResource{}
+	_ resource.Resource                   = &blindfoldFileResource{}
+	_ resource.ResourceWithModifyPlan     = &blindfoldFileResource{}
+	_ resource.ResourceWithConfigure      = &blindfoldFileResource{}
+	_ resource.ResourceWithImportState    = &blindfoldFileResource{}
+	_ resource.ResourceWithValidateConfig = &blindfoldFileResource{}
 )
 
 type blindfoldFileResource struct {
-	client  *http.Client
 	timeout time.Duration
+	cache   *blindfoldCache
 }
 
 type blindfoldFileResourceModel struct {
-	ID             types.String        `tfsdk:"id"`
-	Sealed         types.String        `tfsdk:"sealed"`
-	Path           types.String        `tfsdk:"path"`
-	PolicyDocument policyDocumentModel `tfsdk:"policy_document"`
-	Vesctl         types.String        `tfsdk:"vesctl"`
+	ID                 types.String        `tfsdk:"id"`
+	Sealed             types.String        `tfsdk:"sealed"`
+	Location           types.String        `tfsdk:"location"`
+	SecretInfoJSON     types.String        `tfsdk:"secret_info_json"`
+	DecryptionProvider types.String        `tfsdk:"decryption_provider"`
+	StoreProvider      types.String        `tfsdk:"store_provider"`
+	Path               types.String        `tfsdk:"path"`
+	Source             types.Object        `tfsdk:"source"`
+	ContentHash        types.String        `tfsdk:"content_hash"`
+	PolicyDocument     policyDocumentModel `tfsdk:"policy_document"`
+	Vesctl             types.String        `tfsdk:"vesctl"`
+	Triggers           types.Map           `tfsdk:"triggers"`
+	RotationPeriod     types.String        `tfsdk:"rotation_period"`
+	CreatedAt          types.String        `tfsdk:"created_at"`
+	ReplaceOnRotation  types.Bool          `tfsdk:"replace_on_rotation"`
 }
 
 // NewBlindfoldFileResource creates a new blindfold file Terraform resource and returns a pointer to it.
@@ -53,8 +73,9 @@ func (r *blindfoldFileResource) Metadata(_ context.Context, req resource.Metadat
 // policy document. A definitive path to vesctl can be provided as an option.
 func (r *blindfoldFileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Generates a blindfolded secret from a local file.\n\n" +
-			"This resource does **NOT** add the content of the file to Terraform state.",
+		MarkdownDescription: "Generates a blindfolded secret from a local file, or from a plaintext `source`.\n\n" +
+			"This resource does **NOT** add the content of the file or source to Terraform state; a salted hash of " +
+			"the content is recorded instead so that the secret will be blindfolded again when its content changes.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The computed resource identifier for the blindfolded secret.",
@@ -68,13 +89,57 @@ func (r *blindfoldFileResource) Schema(_ context.Context, _ resource.SchemaReque
 				},
 			},
 			"path": schema.StringAttribute{
-				Description: "The path of the plaintext file that will be blindfolded.",
-				Required:    true,
-				Sensitive:   true,
+				MarkdownDescription: "The path of the plaintext file that will be blindfolded. Exactly one of `path` " +
+					"or `source` must be provided.",
+				Optional:  true,
+				Sensitive: true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIf(
+						requiresReplaceIfPathChanged,
+						"Changing the path will blindfold the file again.",
+						"Changing the path will blindfold the file again.",
+					),
 				},
 			},
+			"source": plaintextSourceSchema("path"),
+			"content_hash": schema.StringAttribute{
+				Description: "A salted HMAC-SHA256 hash of the plaintext file or source content that was blindfolded, " +
+					"used to detect changes to the plaintext without storing an unsalted digest of it.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"location": schema.StringAttribute{
+				MarkdownDescription: "The F5XC location of the sealed data, ready to use as the `location` of a " +
+					"`blindfold_secret_info` block.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"secret_info_json": schema.StringAttribute{
+				MarkdownDescription: "A JSON encoded F5XC `blindfold_secret_info` object that references the sealed " +
+					"data through its `location` field, with `decryption_provider` and `store_provider` fields set " +
+					"from the attributes of the same name.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"decryption_provider": schema.StringAttribute{
+				MarkdownDescription: "An optional name of the F5XC Secret Management Access object that will decrypt " +
+					"the secret, set as the `decryption_provider` field of `secret_info_json`. If unspecified the " +
+					"field is empty and F5XC uses its default. Changing the value does not blindfold the secret again.",
+				Optional: true,
+			},
+			"store_provider": schema.StringAttribute{
+				MarkdownDescription: "An optional name of the F5XC Secret Management Access object of the store that " +
+					"holds the sealed data, set as the `store_provider` field of `secret_info_json`. If unspecified " +
+					"the field is empty and F5XC reads the sealed data from `location`. Changing the value does not " +
+					"blindfold the secret again.",
+				Optional: true,
+			},
 			"policy_document": schema.SingleNestedAttribute{
 				Required: true,
 				Attributes: map[string]schema.Attribute{
@@ -99,6 +164,40 @@ func (r *blindfoldFileResource) Schema(_ context.Context, _ resource.SchemaReque
 					"unspecified, the first vesctl binary found in PATH will be used",
 				Optional: true,
 			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "An arbitrary map of values that, when changed, will cause the secret to be " +
+					"blindfolded again. Adding triggers also blindfolds the secret again, unless it was imported and has not " +
+					"been blindfolded by the provider since.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplaceIf(
+						requiresReplaceIfTriggersChanged,
+						"Changing the triggers will blindfold the secret again.",
+						"Changing the triggers will blindfold the secret again.",
+					),
+				},
+			},
+			"rotation_period": schema.StringAttribute{
+				MarkdownDescription: "An optional duration, such as `720h`, after which the secret will be " +
+					"blindfolded again. The age of the secret is checked against `created_at` during plan.",
+				Optional: true,
+			},
+			"created_at": schema.StringAttribute{
+				Description: "The RFC3339 timestamp of when the secret was blindfolded.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"replace_on_rotation": schema.BoolAttribute{
+				MarkdownDescription: "If true, the default, the secret will be blindfolded again when the tenant's " +
+					"public key is rotated or the secret policy document is changed. If false, a warning will be " +
+					"reported instead.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
 		},
 	}
 }
@@ -117,8 +216,33 @@ func (r *blindfoldFileResource) Configure(_ context.Context, req resource.Config
 		)
 		return
 	}
-	r.client = cfg.client
 	r.timeout = cfg.timeout
+	r.cache = cfg.cache
+}
+
+// Implement the ValidateConfig function for ResourceWithValidateConfig interface. Exactly one of path or source must be
+// set, and rotation_period must be a valid duration.
+func (r *blindfoldFileResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) { //nolint:gocritic // Provider interface passes ValidateConfigRequest by value.
+	resp.Diagnostics.Append(validateRotationPeriod(ctx, &req.Config)...)
+	resp.Diagnostics.Append(validatePlaintextSource(ctx, &req.Config)...)
+	var model blindfoldFileResourceModel
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("path"), &model.Path)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("source"), &model.Source)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	switch {
+	case !model.Path.IsNull() && !model.Source.IsNull():
+		resp.Diagnostics.AddError(
+			"Conflicting plaintext attributes",
+			"Only one of path or source can be set for a blindfold file resource.",
+		)
+	case model.Path.IsNull() && model.Source.IsNull():
+		resp.Diagnostics.AddError(
+			"Missing plaintext attribute",
+			"One of path or source must be set for a blindfold file resource.",
+		)
+	}
 }
 
 // Implement the Create function for Resource interface. Blindfold resources are entirely ephemeral and any change in
@@ -144,34 +268,44 @@ func (r *blindfoldFileResource) Create(ctx context.Context, req resource.CreateR
 	}
 	model.ID = types.StringValue(id.String())
 
-	tflog.Debug(ctx, "Validating file exists at path")
 	plaintextPath := model.Path.ValueString()
-	stat, err := os.Stat(plaintextPath)
-	switch {
-	case err != nil && errors.Is(err, os.ErrNotExist):
-		resp.Diagnostics.AddError(
-			"Error plaintext file does not exist",
-			"The plaintext file expected at "+plaintextPath+" does not exist",
-		)
-		return
-	case err != nil:
-		resp.Diagnostics.AddError(
-			"Error verifying plaintext exists",
-			"Error checking existence of plaintext file at "+plaintextPath+", unexpected error"+err.Error(),
-		)
-		return
-	case stat.IsDir():
-		resp.Diagnostics.AddError(
-			"Error plaintext file is a directory",
-			"The plaintext path expected at "+plaintextPath+" is a directory, not a file",
-		)
-		return
+	var plaintext []byte
+	if model.Path.IsNull() {
+		plaintext, diags = r.readSource(ctx, &req.Config)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		defer clear(plaintext)
+		tflog.Debug(ctx, "Computing plaintext source hash")
+		hash, err := newSaltedHash(bytes.NewReader(plaintext))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error hashing plaintext",
+				"Failed to compute a salted hash of plaintext, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		model.ContentHash = types.StringValue(hash)
+	} else {
+		resp.Diagnostics.Append(checkPlaintextFile(plaintextPath)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		tflog.Debug(ctx, "Computing plaintext file hash")
+		hash, err := newFileHash(plaintextPath)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading plaintext file",
+				"Failed to compute hash of plaintext file at "+plaintextPath+", unexpected error: "+err.Error(),
+			)
+			return
+		}
+		model.ContentHash = types.StringValue(hash)
 	}
 
 	tflog.Debug(ctx, "Fetching Public Key")
-	clientCtx, cancel := context.WithTimeout(ctx, r.timeout)
-	defer cancel()
-	pubKey, err := f5xc.GetPublicKey(clientCtx, r.client, nil)
+	pubKey, err := r.cache.publicKey(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error retrieving PublicKey",
@@ -179,19 +313,9 @@ func (r *blindfoldFileResource) Create(ctx context.Context, req resource.CreateR
 		)
 		return
 	}
-	if pubKey == nil {
-		resp.Diagnostics.AddError(
-			"Error retrieving PublicKey",
-			"PublicKey was not found for this account",
-		)
-		return
-	}
-	cancel()
 
 	tflog.Debug(ctx, "Fetching Secret Policy Document")
-	clientCtx, cancel = context.WithTimeout(ctx, r.timeout)
-	defer cancel()
-	policyDoc, err := f5xc.GetSecretPolicyDocument(clientCtx, r.client, model.PolicyDocument.Name.ValueString(), model.PolicyDocument.Namespace.ValueString())
+	policyDoc, err := r.cache.secretPolicyDocument(ctx, model.PolicyDocument.Name.ValueString(), model.PolicyDocument.Namespace.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error retrieving SecretPolicyDocument",
@@ -199,19 +323,26 @@ func (r *blindfoldFileResource) Create(ctx context.Context, req resource.CreateR
 		)
 		return
 	}
-	if policyDoc == nil {
+
+	rotation, err := newBlindfoldPrivateState(pubKey, policyDoc)
+	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error retrieving SecretPolicyDocument",
-			"SecretPolicyDocument was not found; check the assigned values for name and namespace",
+			"Error recording PublicKey version",
+			"Failed to record PublicKey version and SecretPolicyDocument fingerprint, unexpected error: "+err.Error(),
 		)
 		return
 	}
-	cancel()
+	resp.Diagnostics.Append(rotation.set(ctx, resp.Private)...)
 
 	tflog.Debug(ctx, "Executing blindfold")
-	clientCtx, cancel = context.WithTimeout(ctx, r.timeout)
+	clientCtx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
-	sealed, err := blindfold.SealFile(clientCtx, model.Vesctl.ValueString(), plaintextPath, pubKey, policyDoc)
+	var sealed []byte
+	if model.Path.IsNull() {
+		sealed, err = blindfold.Seal(clientCtx, model.Vesctl.ValueString(), plaintext, pubKey, policyDoc)
+	} else {
+		sealed, err = blindfold.SealFile(clientCtx, model.Vesctl.ValueString(), plaintextPath, pubKey, policyDoc)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error blindfolding data",
@@ -220,6 +351,17 @@ func (r *blindfoldFileResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 	model.Sealed = types.StringValue(string(sealed))
+	location, secretInfo, err := blindfoldSecretInfo(model.Sealed.ValueString(), model.DecryptionProvider.ValueString(), model.StoreProvider.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error encoding blindfold_secret_info",
+			"Failed to encode blindfold_secret_info, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	model.Location = types.StringValue(location)
+	model.SecretInfoJSON = types.StringValue(secretInfo)
+	model.CreatedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
 
 	diags = resp.State.Set(ctx, model)
 	resp.Diagnostics.Append(diags...)
@@ -228,9 +370,147 @@ func (r *blindfoldFileResource) Create(ctx context.Context, req resource.CreateR
 	}
 }
 
-// Implement the Read function for Resource interface. Blindfold resources do not create any state to read in, so this
-// function does nothing. Terraform state will be unchanged.
-func (r *blindfoldFileResource) Read(_ context.Context, _ resource.ReadRequest, _ *resource.ReadResponse) { //nolint:gocritic // Provider interface passes ReadRequest by value.
+// Implement the Read function for Resource interface. The sealed value cannot be verified, but the public key version
+// and secret policy document that were used to blindfold the secret are compared to the current values in F5XC so that
+// the secret can be blindfolded again if either has changed.
+func (r *blindfoldFileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) { //nolint:gocritic // Provider interface passes ReadRequest by value.
+	tflog.Info(ctx, "Reading blindfold resource")
+	var model blindfoldFileResourceModel
+	diags := req.State.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "policy_doc_name", model.PolicyDocument.Name.ValueString())
+	ctx = tflog.SetField(ctx, "policy_doc_namespace", model.PolicyDocument.Namespace.ValueString())
+
+	checkBlindfoldRotation(ctx, r.cache, []policyDocumentModel{model.PolicyDocument}, model.ReplaceOnRotation.ValueBool(), req.Private, resp)
+	checkBlindfoldCreatedAt(ctx, model.CreatedAt, resp)
+	checkBlindfoldSecretInfo(ctx, &req.State, resp)
+}
+
+// Implement the ModifyPlan function for ResourceWithModifyPlan interface. The resource will be replaced if Read has
+// detected that the public key or secret policy document has changed since the secret was blindfolded, or if the
+// content of the plaintext file or source no longer matches the hash recorded when it was blindfolded.
+func (r *blindfoldFileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) { //nolint:gocritic // Provider interface passes ModifyPlanRequest by value.
+	planBlindfoldRotation(ctx, &req, resp)
+	planBlindfoldSecretInfo(ctx, &req, resp)
+	planBlindfoldRotationPeriod(ctx, &req, resp)
+	// Nothing more to do when the resource is being created or destroyed; the hash will be computed by Create.
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	var plaintextPath, recorded types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("path"), &plaintextPath)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("content_hash"), &recorded)...)
+	if resp.Diagnostics.HasError() || plaintextPath.IsUnknown() {
+		return
+	}
+	if plaintextPath.IsNull() {
+		r.planSource(ctx, &req, resp, recorded)
+		return
+	}
+	// Resources created by earlier versions of the provider do not have a recorded hash, so adopt the current content
+	// without blindfolding the file again.
+	if recorded.IsNull() {
+		hash, err := newFileHash(plaintextPath.ValueString())
+		if err != nil {
+			tflog.Debug(ctx, "Unable to compute plaintext file hash during plan", map[string]any{"error": err.Error()})
+			return
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_hash"), types.StringValue(hash))...)
+		return
+	}
+	matches, err := fileHashMatches(recorded.ValueString(), plaintextPath.ValueString())
+	if err != nil {
+		// The file may be generated by another resource during apply, so leave any failure for Create to report.
+		tflog.Debug(ctx, "Unable to compare plaintext file hash during plan", map[string]any{"error": err.Error()})
+		return
+	}
+	if matches {
+		return
+	}
+	tflog.Info(ctx, "Plaintext file content has changed, requiring replacement")
+	planBlindfoldFileReseal(ctx, resp)
+}
+
+// Compares the plaintext read from the source attribute of the config with the recorded hash, and requires replacement
+// if it has changed. A recorded hash is adopted from an imported secret, or one that was blindfolded from a file, as
+// long as the source content matches it.
+func (r *blindfoldFileResource) planSource(ctx context.Context, req *resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, recorded types.String) {
+	var sourceObj types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("source"), &sourceObj)...)
+	source, diags := getPlaintextSource(ctx, &req.Config, path.Root("source"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || sourceObj.IsNull() {
+		return
+	}
+	if hasUnknownValue(sourceObj) {
+		// The source cannot be read until apply, so the secret must be blindfolded again from whatever it returns.
+		tflog.Info(ctx, "Plaintext source is unknown during plan, requiring replacement")
+		planBlindfoldFileReseal(ctx, resp)
+		return
+	}
+	value, err := source.read(ctx, r.timeout)
+	switch {
+	case errors.Is(err, errPlaintextSourceUnknown):
+		tflog.Info(ctx, "Plaintext source is unknown during plan, requiring replacement")
+		planBlindfoldFileReseal(ctx, resp)
+		return
+	case err != nil:
+		// The source may depend on values that are only available during apply, so leave any failure for Create to
+		// report.
+		tflog.Debug(ctx, "Unable to read plaintext source during plan", map[string]any{"error": err.Error()})
+		return
+	}
+	defer clear(value)
+	if recorded.IsNull() {
+		hash, err := newSaltedHash(bytes.NewReader(value))
+		if err != nil {
+			tflog.Debug(ctx, "Unable to compute plaintext source hash during plan", map[string]any{"error": err.Error()})
+			return
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_hash"), types.StringValue(hash))...)
+		return
+	}
+	matches, err := saltedHashMatches(recorded.ValueString(), bytes.NewReader(value))
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("content_hash"),
+			"Unable to compare plaintext with recorded hash",
+			"The recorded content hash could not be parsed and the secret will be blindfolded again, unexpected "+
+				"error: "+err.Error(),
+		)
+	}
+	if matches {
+		return
+	}
+	tflog.Info(ctx, "Plaintext source content has changed, requiring replacement")
+	planBlindfoldFileReseal(ctx, resp)
+}
+
+// Marks the content hash and sealed value as unknown in the plan, and requires the resource to be replaced so that the
+// secret is blindfolded again.
+func planBlindfoldFileReseal(ctx context.Context, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_hash"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("sealed"), types.StringUnknown())...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("content_hash"))
+}
+
+// Implement the ImportState function for ResourceWithImportState interface. Existing sealed values are imported using
+// the same identifier as f5xc_blindfold, of the form namespace/policy_name:sealed, where sealed is the base64 encoded
+// sealed value or the path to a file that contains it. The plaintext file path or source is adopted from config by the
+// next plan, which records the hash of its content without blindfolding the secret again.
+func (r *blindfoldFileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	policy, sealedRef, ok := parseBlindfoldImportID(req.ID)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Invalid import identifier",
+			"Expected an import identifier of the form namespace/policy_name:sealed, got: "+req.ID,
+		)
+		return
+	}
+	resp.Diagnostics.Append(importBlindfoldState(ctx, &policy, sealedRef, &resp.State)...)
 }
 
 // Implement the Update function for Resource interface. Blindfold resources do not create any state to update, so this
@@ -255,3 +535,105 @@ func (r *blindfoldFileResource) Update(ctx context.Context, req resource.UpdateR
 // function does nothing. Terraform state will be deleted as long as the function does not add diagnostics to the response.
 func (r *blindfoldFileResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) { //nolint:gocritic // Provider interface passes DeleteRequest by value.
 }
+
+// Requires replacement when the plaintext file path is changed. Imported resources, and those blindfolded from a
+// source, do not have a path in state, so the configured path is adopted and its content is compared with the recorded
+// hash instead. The same comparison is made when the path is replaced by a source.
+func requiresReplaceIfPathChanged(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) { //nolint:gocritic // RequiresReplaceIfFunc passes StringRequest by value.
+	resp.RequiresReplace = !req.StateValue.IsNull() && !req.PlanValue.IsNull()
+}
+
+// Returns diagnostics if the plaintext file at plaintextPath does not exist or is a directory.
+func checkPlaintextFile(plaintextPath string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	stat, err := os.Stat(plaintextPath)
+	switch {
+	case err != nil && errors.Is(err, os.ErrNotExist):
+		diags.AddError(
+			"Error plaintext file does not exist",
+			"The plaintext file expected at "+plaintextPath+" does not exist",
+		)
+	case err != nil:
+		diags.AddError(
+			"Error verifying plaintext exists",
+			"Error checking existence of plaintext file at "+plaintextPath+", unexpected error"+err.Error(),
+		)
+	case stat.IsDir():
+		diags.AddError(
+			"Error plaintext file is a directory",
+			"The plaintext path expected at "+plaintextPath+" is a directory, not a file",
+		)
+	}
+	return diags
+}
+
+// Returns the plaintext value read from the source attribute of the config.
+func (r *blindfoldFileResource) readSource(ctx context.Context, config *tfsdk.Config) ([]byte, diag.Diagnostics) {
+	source, diags := getPlaintextSource(ctx, config, path.Root("source"))
+	if diags.HasError() {
+		return nil, diags
+	}
+	if source == nil {
+		diags.AddAttributeError(
+			path.Root("source"),
+			"Missing plaintext source",
+			"The plaintext source is not set, or has values that are unknown.",
+		)
+		return nil, diags
+	}
+	tflog.Debug(ctx, "Reading plaintext value from source")
+	value, err := source.read(ctx, r.timeout)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("source"),
+			"Error reading plaintext source",
+			"Failed to read plaintext from source, unexpected error: "+err.Error(),
+		)
+		return nil, diags
+	}
+	return value, diags
+}
+
+// Returns a salted hash of the content of the file at plaintextPath, in the same format as newPlaintextHash.
+func newFileHash(plaintextPath string) (string, error) {
+	f, err := os.Open(plaintextPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	hash, err := newSaltedHash(f)
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to close file: %w", closeErr)
+	}
+	return hash, err
+}
+
+// Returns true if the content of the file at plaintextPath matches the salted hash.
+func fileHashMatches(hash, plaintextPath string) (bool, error) {
+	f, err := os.Open(plaintextPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to open file: %w", err)
+	}
+	matches, err := saltedHashMatches(hash, f)
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to close file: %w", closeErr)
+	}
+	return matches, err
+}
+
+// Returns the hex encoded SHA-256 digest of the file at filePath. The digest is not salted, so it must only be used for
+// content that is not secret, such as encrypted files.
+func fileSHA256(filePath string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	hash := sha256.New()
+	_, err = io.Copy(hash, f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestFileHash(t *testing.T) {
+	t.Parallel()
+	plaintextPath := filepath.Join(t.TempDir(), "plaintext")
+	if err := os.WriteFile(plaintextPath, []byte("This is a plaintext document"), 0o600); err != nil {
+		t.Fatalf("failed to write plaintext file: %v", err)
+	}
+	hash, err := newFileHash(plaintextPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(hash, "c5c44c0b2cdbf8f79b237792ac88626277ae9954a90396d22837a437fecdcf96") {
+		t.Errorf("expected hash to be salted, got an unsalted SHA-256 digest: %s", hash)
+	}
+	other, err := newFileHash(plaintextPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hash == other {
+		t.Error("expected hashes of the same content to use different salts")
+	}
+	matches, err := fileHashMatches(hash, plaintextPath)
+	if err != nil || !matches {
+		t.Errorf("expected hash to match unchanged file, got %t: %v", matches, err)
+	}
+	if err := os.WriteFile(plaintextPath, []byte("This is a changed plaintext document"), 0o600); err != nil {
+		t.Fatalf("failed to update plaintext file: %v", err)
+	}
+	matches, err = fileHashMatches(hash, plaintextPath)
+	if err != nil || matches {
+		t.Errorf("expected hash not to match changed file, got %t: %v", matches, err)
+	}
+	if _, err := fileHashMatches(hash, filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+	if _, err := fileHashMatches("not-a-hash", plaintextPath); err == nil {
+		t.Error("expected an error for an invalid hash")
+	}
+}
+
+func TestBlindfoldFileResourceImportState(t *testing.T) {
+	t.Parallel()
+	sealedFile := filepath.Join(t.TempDir(), "sealed.txt")
+	if err := os.WriteFile(sealedFile, []byte("c2VhbGVkIGZyb20gZmlsZQ==\n"), 0o600); err != nil {
+		t.Fatalf("failed to write sealed file: %v", err)
+	}
+	tests := []struct {
+		name           string
+		id             string
+		expectedSealed string
+		expectedError  bool
+	}{
+		{name: "literal", id: "shared/policy:c2VhbGVk", expectedSealed: "c2VhbGVk"},
+		{name: "file", id: "shared/policy:" + sealedFile, expectedSealed: "c2VhbGVkIGZyb20gZmlsZQ=="},
+		{name: "plaintext-path", id: "shared/policy:" + sealedFile + ":/path/to/plaintext", expectedError: true},
+		{name: "invalid-id", id: "shared:c2VhbGVk", expectedError: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			state := testImportBlindfoldState(t, NewBlindfoldFileResource(), test.id, test.expectedError)
+			if test.expectedError {
+				return
+			}
+			var sealed, plaintextPath, contentHash types.String
+			state.GetAttribute(context.Background(), path.Root("sealed"), &sealed)
+			state.GetAttribute(context.Background(), path.Root("path"), &plaintextPath)
+			state.GetAttribute(context.Background(), path.Root("content_hash"), &contentHash)
+			if sealed.ValueString() != test.expectedSealed {
+				t.Errorf("expected sealed %q, got %q", test.expectedSealed, sealed.ValueString())
+			}
+			// The plaintext path and hash are adopted from config by the next plan.
+			if !plaintextPath.IsNull() || !contentHash.IsNull() {
+				t.Errorf("expected path and content_hash to be null, got %s and %s", plaintextPath, contentHash)
+			}
+		})
+	}
+}
+
+// A plaintext source must be compared with the recorded hash, and only blindfolded again when its content has changed.
+func TestBlindfoldFileResourceModifyPlanSource(t *testing.T) {
+	t.Parallel()
+	plaintextFile := filepath.Join(t.TempDir(), "plaintext")
+	if err := os.WriteFile(plaintextFile, []byte("This is a test"), 0o600); err != nil {
+		t.Fatalf("failed to write plaintext file: %v", err)
+	}
+	hash, err := newPlaintextHash("This is a test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	otherHash, err := newPlaintextHash("This is another test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []struct {
+		name             string
+		recorded         types.String
+		expectedReplaced bool
+	}{
+		{name: "unchanged", recorded: types.StringValue(hash)},
+		{name: "changed", recorded: types.StringValue(otherHash), expectedReplaced: true},
+		{name: "imported", recorded: types.StringNull()},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			attrTypes := plaintextSourceAttrTypes()
+			source := types.ObjectValueMust(attrTypes, map[string]attr.Value{
+				"env":          types.StringNull(),
+				"file":         types.StringValue(plaintextFile),
+				"command":      types.ObjectNull(attrTypes["command"].(types.ObjectType).AttrTypes), //nolint:forcetypeassert // command is a nested object.
+				"trim_newline": types.BoolNull(),
+			})
+			schemaResp := &resource.SchemaResponse{}
+			(&blindfoldFileResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			if diags := state.Set(ctx, &blindfoldFileResourceModel{
+				ID:          types.StringValue("test"),
+				Sealed:      types.StringValue("sealed"),
+				Source:      source,
+				ContentHash: test.recorded,
+				PolicyDocument: policyDocumentModel{
+					Name:      types.StringValue("ves-io-allow-volterra"),
+					Namespace: types.StringValue("shared"),
+				},
+				Triggers:          types.MapNull(types.StringType),
+				ReplaceOnRotation: types.BoolValue(true),
+			}); diags.HasError() {
+				t.Fatalf("failed to set state: %v", diags)
+			}
+			resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: state.Schema, Raw: state.Raw.Copy()}}
+			(&blindfoldFileResource{timeout: 10 * time.Second}).ModifyPlan(ctx, resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: state.Schema, Raw: state.Raw},
+				State:  state,
+				Plan:   tfsdk.Plan{Schema: state.Schema, Raw: state.Raw},
+			}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if replaced := len(resp.RequiresReplace) > 0; replaced != test.expectedReplaced {
+				t.Errorf("expected replacement to be %t, got %v", test.expectedReplaced, resp.RequiresReplace)
+			}
+			var planned types.String
+			resp.Plan.GetAttribute(ctx, path.Root("content_hash"), &planned)
+			if test.recorded.IsNull() {
+				if matches, err := plaintextHashMatches(planned.ValueString(), "This is a test"); err != nil || !matches {
+					t.Errorf("expected imported content_hash to be adopted from source, got %s", planned)
+				}
+			}
+		})
+	}
+}
@@ -3,9 +3,12 @@ package provider_test
 import (
 	"errors"
 	"os"
+	"path/filepath"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
 func TestAccBlindfoldFileResource(t *testing.T) {
@@ -26,13 +29,82 @@ func TestAccBlindfoldFileResource(t *testing.T) {
 	if err := tmpFile.Close(); err != nil {
 		t.Errorf("failed to close plaintext file: %v", err)
 	}
+	config := providerConfig + `
+resource "f5xc_blindfold_file" "test" {
+	path = "` + tmpFile.Name() + `"
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+}
+`
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("f5xc_blindfold_file.test", "id"),
+					resource.TestCheckResourceAttr("f5xc_blindfold_file.test", "path", tmpFile.Name()),
+					resource.TestMatchResourceAttr("f5xc_blindfold_file.test", "content_hash", regexp.MustCompile(`^[0-9a-f]{32}:[0-9a-f]{64}$`)),
+					resource.TestCheckResourceAttr("f5xc_blindfold_file.test", "replace_on_rotation", "true"),
+					resource.TestMatchResourceAttr("f5xc_blindfold_file.test", "location", regexp.MustCompile(`^string:///.+`)),
+					resource.TestMatchResourceAttr("f5xc_blindfold_file.test", "secret_info_json", regexp.MustCompile(`"location":"string:///.+"`)),
+					resource.TestCheckResourceAttrSet("f5xc_blindfold_file.test", "sealed"),
+				),
+			},
+			// Importing the sealed value must adopt the configured path without blindfolding the file again.
+			{
+				ResourceName:      "f5xc_blindfold_file.test",
+				ImportState:       true,
+				ImportStateKind:   resource.ImportBlockWithID,
+				ImportStateIdFunc: testAccBlindfoldImportStateIDFunc("f5xc_blindfold_file.test"),
+				ImportPlanChecks: resource.ImportPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("f5xc_blindfold_file.test", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+			// Changing the content of the file must blindfold it again.
+			{
+				PreConfig: func() {
+					if err := os.WriteFile(tmpFile.Name(), []byte("This is an updated plaintext document"), 0o600); err != nil {
+						t.Errorf("failed to update plaintext file: %v", err)
+					}
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("f5xc_blindfold_file.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("f5xc_blindfold_file.test", "content_hash", regexp.MustCompile(`^[0-9a-f]{32}:[0-9a-f]{64}$`)),
+					resource.TestCheckResourceAttrSet("f5xc_blindfold_file.test", "sealed"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBlindfoldFileResourceSource(t *testing.T) {
+	t.Parallel()
+	plaintextFile := filepath.Join(t.TempDir(), "plaintext")
+	if err := os.WriteFile(plaintextFile, []byte("This is a plaintext document to be blindfolded"), 0o600); err != nil {
+		t.Fatalf("failed to write plaintext file: %v", err)
+	}
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: providerConfig + `
 resource "f5xc_blindfold_file" "test" {
-	path = "` + tmpFile.Name() + `"
+	source = {
+		command = {
+			program = "cat"
+			args = ["` + plaintextFile + `"]
+		}
+	}
 	policy_document = {
 		name = "ves-io-allow-volterra"
 		namespace = "shared"
@@ -40,11 +112,44 @@ resource "f5xc_blindfold_file" "test" {
 }
 `,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttrSet("f5xc_blindfold_file.test", "id"),
-					resource.TestCheckResourceAttr("f5xc_blindfold_file.test", "path", tmpFile.Name()),
+					resource.TestCheckNoResourceAttr("f5xc_blindfold_file.test", "path"),
+					resource.TestCheckResourceAttr("f5xc_blindfold_file.test", "source.command.program", "cat"),
+					resource.TestMatchResourceAttr("f5xc_blindfold_file.test", "content_hash", regexp.MustCompile(`^[0-9a-f]{32}:[0-9a-f]{64}$`)),
 					resource.TestCheckResourceAttrSet("f5xc_blindfold_file.test", "sealed"),
 				),
 			},
+			// Reading the same content from a path must not blindfold it again.
+			{
+				Config: providerConfig + `
+resource "f5xc_blindfold_file" "test" {
+	path = "` + plaintextFile + `"
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("f5xc_blindfold_file.test", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+			{
+				Config: providerConfig + `
+resource "f5xc_blindfold_file" "test" {
+	path = "` + plaintextFile + `"
+	source = {
+		file = "` + plaintextFile + `"
+	}
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+}
+`,
+				ExpectError: regexp.MustCompile(`Conflicting plaintext attributes`),
+			},
 		},
 	})
 }
@@ -0,0 +1,591 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/memes/f5xc"
+	"github.com/memes/f5xc/blindfold"
+)
+
+var (
+	_ resource.Resource                   = &blindfoldFilesResource{}
+	_ resource.ResourceWithModifyPlan     = &blindfoldFilesResource{}
+	_ resource.ResourceWithConfigure      = &blindfoldFilesResource{}
+	_ resource.ResourceWithValidateConfig = &blindfoldFilesResource{}
+)
+
+type blindfoldFilesResource struct {
+	timeout time.Duration
+	cache   *blindfoldCache
+}
+
+type blindfoldFilesResourceModel struct {
+	ID                types.String        `tfsdk:"id"`
+	Sealed            types.Map           `tfsdk:"sealed"`
+	Directory         types.String        `tfsdk:"directory"`
+	Include           types.List          `tfsdk:"include"`
+	Exclude           types.List          `tfsdk:"exclude"`
+	ContentHash       types.Map           `tfsdk:"content_hash"`
+	PolicyDocument    policyDocumentModel `tfsdk:"policy_document"`
+	Vesctl            types.String        `tfsdk:"vesctl"`
+	Parallelism       types.Int64         `tfsdk:"parallelism"`
+	ReplaceOnRotation types.Bool          `tfsdk:"replace_on_rotation"`
+}
+
+// NewBlindfoldFilesResource creates a new blindfold files Terraform resource and returns a pointer to it.
+func NewBlindfoldFilesResource() resource.Resource {
+	return &blindfoldFilesResource{}
+}
+
+// Implement the Metadata function for Resource interface.
+func (r *blindfoldFilesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_blindfold_files"
+}
+
+// Implement the Schema function for Resource interface. Blindfold files resources are configured with a directory and
+// optional glob patterns to select the files that will be blindfolded. A salted hash of each file is stored in state so
+// that only files that are added or changed are blindfolded again.
+func (r *blindfoldFilesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Generates blindfolded secrets from every file in a local directory that matches a set " +
+			"of glob patterns.\n\n" +
+			"This resource does **NOT** add the content of the files to Terraform state; a salted hash of each " +
+			"file is recorded instead so that only the files that are added or changed will be blindfolded again.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The computed resource identifier for the blindfolded secrets.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"sealed": schema.MapAttribute{
+				Description: "A map of the path of each file, relative to directory, to the base64 encoded, sealed data " +
+					"resulting from a blindfold of the file.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"directory": schema.StringAttribute{
+				Description: "The path of the directory containing plaintext files that will be blindfolded.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"include": schema.ListAttribute{
+				MarkdownDescription: "An optional list of glob patterns, relative to `directory`, of files that will be " +
+					"blindfolded. A `**` path element matches any number of directories. If unspecified, every file in " +
+					"`directory` will be blindfolded.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"exclude": schema.ListAttribute{
+				MarkdownDescription: "An optional list of glob patterns, relative to `directory`, of files that will " +
+					"not be blindfolded even if they match an `include` pattern.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"content_hash": schema.MapAttribute{
+				Description: "A map of the path of each file, relative to directory, to a salted HMAC-SHA256 hash of " +
+					"the file content that was blindfolded, used to detect changes to the files without storing an " +
+					"unsalted digest of the plaintext.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"policy_document": schema.SingleNestedAttribute{
+				Required: true,
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Description: "The name of the PolicyDocument to use for blindfold.",
+						Required:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"namespace": schema.StringAttribute{
+						Description: "The namespace of the PolicyDocument to use for blindfold.",
+						Required:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+				},
+			},
+			"vesctl": schema.StringAttribute{
+				MarkdownDescription: "The path to `vesctl` binary to use for blindfolding. If " +
+					"unspecified, the first vesctl binary found in PATH will be used",
+				Optional: true,
+			},
+			"parallelism": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of files that will be blindfolded "+
+					"concurrently. Defaults to %d.", defaultBlindfoldParallelism),
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(defaultBlindfoldParallelism),
+			},
+			"replace_on_rotation": schema.BoolAttribute{
+				MarkdownDescription: "If true, the default, all files will be blindfolded again when the tenant's " +
+					"public key is rotated or the secret policy document is changed. If false, a warning will be " +
+					"reported instead.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+// Implement the Configure function for Resource interface.
+func (r *blindfoldFilesResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*f5XCConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *f5XCConfig, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.timeout = cfg.timeout
+	r.cache = cfg.cache
+}
+
+// Implement the ValidateConfig function for ResourceWithValidateConfig interface. The parallelism must be positive, and
+// the include and exclude patterns must be valid globs.
+func (r *blindfoldFilesResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) { //nolint:gocritic // Provider interface passes ValidateConfigRequest by value.
+	resp.Diagnostics.Append(validateParallelism(ctx, &req.Config)...)
+	for _, attrName := range []string{"include", "exclude"} {
+		var patterns types.List
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attrName), &patterns)...)
+		if resp.Diagnostics.HasError() || patterns.IsNull() || patterns.IsUnknown() {
+			continue
+		}
+		for i, element := range patterns.Elements() {
+			pattern, ok := element.(types.String)
+			if !ok || pattern.IsNull() || pattern.IsUnknown() {
+				continue
+			}
+			if err := validateGlob(pattern.ValueString()); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(attrName).AtListIndex(i),
+					"Invalid glob pattern",
+					fmt.Sprintf("The pattern %q is not a valid glob, unexpected error: %v", pattern.ValueString(), err),
+				)
+			}
+		}
+	}
+}
+
+// Implement the Create function for Resource interface. Every matching file in the directory is blindfolded.
+func (r *blindfoldFilesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) { //nolint:gocritic // Provider interface passes CreateRequest by value.
+	tflog.Info(ctx, "Creating blindfold files resource")
+	var model blindfoldFilesResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "policy_doc_name", model.PolicyDocument.Name.ValueString())
+	ctx = tflog.SetField(ctx, "policy_doc_namespace", model.PolicyDocument.Namespace.ValueString())
+	ctx = tflog.SetField(ctx, "vesctl", model.Vesctl.ValueString())
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error computing id",
+			"Failed to compute a new id for the resource, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	model.ID = types.StringValue(id.String())
+
+	relPaths, diags := model.listFiles(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	hashes, diags := model.hashFiles(relPaths)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pubKey, policyDoc, diags := fetchBlindfoldInputs(ctx, r.cache, &model.PolicyDocument)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	rotation, err := newBlindfoldPrivateState(pubKey, policyDoc)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error recording PublicKey version",
+			"Failed to record PublicKey version and SecretPolicyDocument fingerprint, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(rotation.set(ctx, resp.Private)...)
+
+	sealed, diags := r.seal(ctx, &model, pubKey, policyDoc, relPaths)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(model.setSealed(ctx, sealed, hashes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Implement the Read function for Resource interface. The sealed values cannot be verified, but the public key version
+// and secret policy document that were used to blindfold the files are compared to the current values in F5XC so that
+// the files can be blindfolded again if either has changed.
+func (r *blindfoldFilesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) { //nolint:gocritic // Provider interface passes ReadRequest by value.
+	tflog.Info(ctx, "Reading blindfold files resource")
+	var model blindfoldFilesResourceModel
+	diags := req.State.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "policy_doc_name", model.PolicyDocument.Name.ValueString())
+	ctx = tflog.SetField(ctx, "policy_doc_namespace", model.PolicyDocument.Namespace.ValueString())
+
+	checkBlindfoldRotation(ctx, r.cache, []policyDocumentModel{model.PolicyDocument}, model.ReplaceOnRotation.ValueBool(), req.Private, resp)
+}
+
+// Implement the ModifyPlan function for ResourceWithModifyPlan interface. The resource will be replaced if Read has
+// detected that the public key or secret policy document has changed since the files were blindfolded; otherwise each
+// matching file is compared with its recorded hash and the sealed values of new or changed files are marked as unknown
+// so that Update will blindfold them again. The plan is left unchanged if the matching files cannot be listed.
+func (r *blindfoldFilesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) { //nolint:gocritic // Provider interface passes ModifyPlanRequest by value.
+	planBlindfoldRotation(ctx, &req, resp)
+	// Nothing more to do when the resource is being created, replaced, or destroyed.
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || len(resp.RequiresReplace) > 0 {
+		return
+	}
+	var model blindfoldFilesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	var recordedSealed, recordedHashes map[string]string
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("sealed"), &recordedSealed)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("content_hash"), &recordedHashes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if model.Include.IsUnknown() || model.Exclude.IsUnknown() {
+		tflog.Debug(ctx, "File patterns are unknown during plan, all files will be blindfolded again")
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("sealed"), types.MapUnknown(types.StringType))...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_hash"), types.MapUnknown(types.StringType))...)
+		return
+	}
+	relPaths, diags := model.listFiles(ctx)
+	if diags.HasError() {
+		// The directory may be populated by another resource during apply, so leave any failure for Update to report.
+		tflog.Debug(ctx, "Unable to list plaintext files during plan", map[string]any{"error": diags.Errors()[0].Detail()})
+		return
+	}
+
+	directory := model.Directory.ValueString()
+	plannedSealed := make(map[string]attr.Value, len(relPaths))
+	plannedHashes := make(map[string]attr.Value, len(relPaths))
+	for _, relPath := range relPaths {
+		sealed, sealedOK := recordedSealed[relPath]
+		hash, hashOK := recordedHashes[relPath]
+		if sealedOK && hashOK {
+			matches, err := fileHashMatches(hash, filepath.Join(directory, filepath.FromSlash(relPath)))
+			if err != nil {
+				// Unreadable files are blindfolded again so that Update will report the failure.
+				tflog.Debug(ctx, "Unable to compare plaintext file hash during plan", map[string]any{"file": relPath, "error": err.Error()})
+			}
+			if matches {
+				plannedSealed[relPath] = types.StringValue(sealed)
+				plannedHashes[relPath] = types.StringValue(hash)
+				continue
+			}
+		}
+		tflog.Debug(ctx, "Plaintext file is new or has changed", map[string]any{"file": relPath})
+		plannedSealed[relPath] = types.StringUnknown()
+		plannedHashes[relPath] = types.StringUnknown()
+	}
+	sealedMap, diags := types.MapValue(types.StringType, plannedSealed)
+	resp.Diagnostics.Append(diags...)
+	hashesMap, diags := types.MapValue(types.StringType, plannedHashes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("sealed"), sealedMap)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_hash"), hashesMap)...)
+}
+
+// Implement the Update function for Resource interface. Only the files that have been marked as unknown by ModifyPlan
+// are blindfolded again, and files that no longer match are removed from state.
+func (r *blindfoldFilesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) { //nolint:gocritic // Provider interface passes UpdateRequest by value.
+	tflog.Info(ctx, "Updating blindfold files resource")
+	var model blindfoldFilesResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "policy_doc_name", model.PolicyDocument.Name.ValueString())
+	ctx = tflog.SetField(ctx, "policy_doc_namespace", model.PolicyDocument.Namespace.ValueString())
+	ctx = tflog.SetField(ctx, "vesctl", model.Vesctl.ValueString())
+
+	// The planned hashes are used when they are known, so that the result matches the plan even if a file has changed
+	// since the plan was created; the change will be detected by the next plan.
+	var plannedSealed, plannedHashes map[string]types.String
+	if model.Sealed.IsUnknown() || model.ContentHash.IsUnknown() {
+		relPaths, diags := model.listFiles(ctx)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		plannedSealed = make(map[string]types.String, len(relPaths))
+		plannedHashes = make(map[string]types.String, len(relPaths))
+		for _, relPath := range relPaths {
+			plannedSealed[relPath] = types.StringUnknown()
+			plannedHashes[relPath] = types.StringUnknown()
+		}
+	} else {
+		resp.Diagnostics.Append(model.Sealed.ElementsAs(ctx, &plannedSealed, false)...)
+		resp.Diagnostics.Append(model.ContentHash.ElementsAs(ctx, &plannedHashes, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	sealed := make(map[string]string, len(plannedSealed))
+	hashes := make(map[string]string, len(plannedHashes))
+	var changed []string
+	for relPath, value := range plannedSealed {
+		if value.IsUnknown() || plannedHashes[relPath].IsUnknown() {
+			changed = append(changed, relPath)
+			continue
+		}
+		sealed[relPath] = value.ValueString()
+		hashes[relPath] = plannedHashes[relPath].ValueString()
+	}
+
+	if len(changed) > 0 {
+		pubKey, policyDoc, diags := fetchBlindfoldInputs(ctx, r.cache, &model.PolicyDocument)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		slices.Sort(changed)
+		changedHashes, diags := model.hashFiles(changed)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		maps.Copy(hashes, changedHashes)
+		values, diags := r.seal(ctx, &model, pubKey, policyDoc, changed)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for relPath, value := range values {
+			sealed[relPath] = value
+		}
+	}
+	resp.Diagnostics.Append(model.setSealed(ctx, sealed, hashes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Implement the Delete function for Resource interface. Blindfold resources do not create any state to clean up, so this
+// function does nothing. Terraform state will be deleted as long as the function does not add diagnostics to the response.
+func (r *blindfoldFilesResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) { //nolint:gocritic // Provider interface passes DeleteRequest by value.
+}
+
+// Blindfolds each of the files, identified by the path relative to the directory, and returns a map of relative path to
+// sealed value.
+func (r *blindfoldFilesResource) seal(ctx context.Context, model *blindfoldFilesResourceModel, pubKey *f5xc.PublicKey, policyDoc *f5xc.SecretPolicyDocument, relPaths []string) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	directory := model.Directory.ValueString()
+	sealed, err := sealConcurrently(ctx, model.Parallelism.ValueInt64(), relPaths, func(ctx context.Context, relPath string) (string, error) {
+		clientCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+		value, err := blindfold.SealFile(clientCtx, model.Vesctl.ValueString(), filepath.Join(directory, filepath.FromSlash(relPath)), pubKey, policyDoc)
+		if err != nil {
+			return "", fmt.Errorf("failed to blindfold %q: %w", relPath, err)
+		}
+		return string(value), nil
+	})
+	if err != nil {
+		diags.AddError(
+			"Error blindfolding data",
+			"Failed to blindfold data, unexpected error: "+err.Error(),
+		)
+	}
+	return sealed, diags
+}
+
+// Returns the sorted paths, relative to the directory, of every file that matches the include and exclude patterns.
+func (m *blindfoldFilesResourceModel) listFiles(ctx context.Context) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var include, exclude []string
+	if !m.Include.IsNull() {
+		diags.Append(m.Include.ElementsAs(ctx, &include, false)...)
+	}
+	if !m.Exclude.IsNull() {
+		diags.Append(m.Exclude.ElementsAs(ctx, &exclude, false)...)
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+	directory := m.Directory.ValueString()
+	relPaths, err := matchFiles(directory, include, exclude)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("directory"),
+			"Error listing plaintext files",
+			"Failed to list plaintext files in "+directory+", unexpected error: "+err.Error(),
+		)
+		return nil, diags
+	}
+	return relPaths, diags
+}
+
+// Returns a map of each of the files, identified by the path relative to the directory, to a salted hash of the file.
+func (m *blindfoldFilesResourceModel) hashFiles(relPaths []string) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	directory := m.Directory.ValueString()
+	hashes := make(map[string]string, len(relPaths))
+	for _, relPath := range relPaths {
+		hash, err := newFileHash(filepath.Join(directory, filepath.FromSlash(relPath)))
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("directory"),
+				"Error reading plaintext file",
+				"Failed to compute hash of plaintext file "+relPath+", unexpected error: "+err.Error(),
+			)
+			return nil, diags
+		}
+		hashes[relPath] = hash
+	}
+	return hashes, diags
+}
+
+// Sets the sealed and content_hash attributes of the model.
+func (m *blindfoldFilesResourceModel) setSealed(ctx context.Context, sealed, hashes map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	sealedMap, d := types.MapValueFrom(ctx, types.StringType, sealed)
+	diags.Append(d...)
+	hashesMap, d := types.MapValueFrom(ctx, types.StringType, hashes)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	m.Sealed = sealedMap
+	m.ContentHash = hashesMap
+	return diags
+}
+
+// Returns the slash separated paths, relative to directory, of the regular files in directory that match any of the
+// include patterns and none of the exclude patterns. Every file matches if include is empty.
+func matchFiles(directory string, include, exclude []string) ([]string, error) {
+	var relPaths []string
+	err := filepath.WalkDir(directory, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(directory, filePath)
+		if err != nil {
+			return fmt.Errorf("failed to get relative path: %w", err)
+		}
+		rel = filepath.ToSlash(rel)
+		if (len(include) > 0 && !matchesAnyGlob(include, rel)) || matchesAnyGlob(exclude, rel) {
+			return nil
+		}
+		// Follow symbolic links, but only blindfold regular files.
+		stat, err := os.Stat(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to stat file: %w", err)
+		}
+		if stat.Mode().IsRegular() {
+			relPaths = append(relPaths, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk directory: %w", err)
+	}
+	return relPaths, nil
+}
+
+// Returns true if the slash separated name matches any of the glob patterns.
+func matchesAnyGlob(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matchGlob(strings.Split(pattern, "/"), strings.Split(name, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if the path elements match the pattern elements, where a ** pattern element matches zero or more path
+// elements and all other pattern elements follow the syntax of path.Match.
+func matchGlob(patterns, names []string) bool {
+	for len(patterns) > 0 {
+		if patterns[0] == "**" {
+			for i := range len(names) + 1 {
+				if matchGlob(patterns[1:], names[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(names) == 0 {
+			return false
+		}
+		if matched, err := filepath.Match(patterns[0], names[0]); err != nil || !matched {
+			return false
+		}
+		patterns, names = patterns[1:], names[1:]
+	}
+	return len(names) == 0
+}
+
+// Returns an error if any element of the slash separated glob pattern is malformed.
+func validateGlob(pattern string) error {
+	for _, element := range strings.Split(pattern, "/") {
+		if _, err := filepath.Match(element, ""); err != nil {
+			return fmt.Errorf("invalid pattern element %q: %w", element, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestMatchGlob(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		pattern  string
+		name     string
+		expected bool
+	}{
+		{pattern: "*.json", name: "first.json", expected: true},
+		{pattern: "*.json", name: "site/second.json"},
+		{pattern: "site/*.json", name: "site/second.json", expected: true},
+		{pattern: "site/?econd.json", name: "site/second.json", expected: true},
+		{pattern: "site/[a-s]*.json", name: "site/second.json", expected: true},
+		{pattern: "site/[^s]*.json", name: "site/second.json"},
+		{pattern: "**", name: "first.json", expected: true},
+		{pattern: "**", name: "site/deep/third.json", expected: true},
+		{pattern: "**/*.json", name: "first.json", expected: true},
+		{pattern: "**/*.json", name: "site/deep/third.json", expected: true},
+		{pattern: "**/*.json", name: "site/ignored.txt"},
+		{pattern: "site/**", name: "site/second.json", expected: true},
+		{pattern: "site/**", name: "other/second.json"},
+		{pattern: "site/**/third.json", name: "site/third.json", expected: true},
+		{pattern: "site/**/third.json", name: "site/deep/deeper/third.json", expected: true},
+		{pattern: "site/**/third.json", name: "site/deep/second.json"},
+		{pattern: "**/deep/**", name: "site/deep/third.json", expected: true},
+		{pattern: "**/deep/**", name: "site/third.json"},
+		{pattern: "site", name: "site/second.json"},
+		{pattern: "site/second.json/**", name: "site/second.json", expected: true},
+		{pattern: "[", name: "["},
+	}
+	for _, test := range tests {
+		t.Run(test.pattern+" "+test.name, func(t *testing.T) {
+			t.Parallel()
+			if result := matchGlob(strings.Split(test.pattern, "/"), strings.Split(test.name, "/")); result != test.expected {
+				t.Errorf("expected %q to match %q to be %t, got %t", test.pattern, test.name, test.expected, result)
+			}
+		})
+	}
+}
+
+func TestValidateGlob(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		pattern       string
+		expectedError bool
+	}{
+		{pattern: "*.json"},
+		{pattern: "**/*.json"},
+		{pattern: "site/[a-z]*/**"},
+		{pattern: "[", expectedError: true},
+		{pattern: "site/[a-/*.json", expectedError: true},
+		{pattern: "**/[", expectedError: true},
+	}
+	for _, test := range tests {
+		t.Run(test.pattern, func(t *testing.T) {
+			t.Parallel()
+			if err := validateGlob(test.pattern); (err != nil) != test.expectedError {
+				t.Errorf("expected error to be %t, got %v", test.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestMatchFiles(t *testing.T) {
+	t.Parallel()
+	directory := t.TempDir()
+	for _, name := range []string{"first.json", "site/second.json", "site/ignored.txt", "site/excluded.json", "site/deep/third.json"} {
+		filePath := filepath.Join(directory, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filePath), 0o700); err != nil {
+			t.Fatalf("failed to create directory for %s: %v", name, err)
+		}
+		if err := os.WriteFile(filePath, []byte(name), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	tests := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		expected []string
+	}{
+		{
+			name:     "all",
+			expected: []string{"first.json", "site/deep/third.json", "site/excluded.json", "site/ignored.txt", "site/second.json"},
+		},
+		{
+			name:     "include",
+			include:  []string{"**/*.json"},
+			expected: []string{"first.json", "site/deep/third.json", "site/excluded.json", "site/second.json"},
+		},
+		{
+			name:     "exclude",
+			include:  []string{"**/*.json"},
+			exclude:  []string{"**/excluded.json", "site/deep/**"},
+			expected: []string{"first.json", "site/second.json"},
+		},
+		{
+			name:    "none",
+			include: []string{"*.yaml"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			relPaths, err := matchFiles(directory, test.include, test.exclude)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(relPaths, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, relPaths)
+			}
+		})
+	}
+	if _, err := matchFiles(filepath.Join(directory, "missing"), nil, nil); err == nil {
+		t.Error("expected an error for a missing directory")
+	}
+}
+
+// A directory that cannot be listed during plan must leave the plan unchanged, so that Update reports the failure.
+func TestBlindfoldFilesResourceModifyPlanMissingDirectory(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	(&blindfoldFilesResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	if diags := state.Set(ctx, &blindfoldFilesResourceModel{
+		ID:          types.StringValue("test"),
+		Sealed:      types.MapValueMust(types.StringType, map[string]attr.Value{"first.json": types.StringValue("sealed")}),
+		Directory:   types.StringValue(filepath.Join(t.TempDir(), "missing")),
+		Include:     types.ListNull(types.StringType),
+		Exclude:     types.ListNull(types.StringType),
+		ContentHash: types.MapValueMust(types.StringType, map[string]attr.Value{"first.json": types.StringValue("hash")}),
+		PolicyDocument: policyDocumentModel{
+			Name:      types.StringValue("ves-io-allow-volterra"),
+			Namespace: types.StringValue("shared"),
+		},
+		Vesctl:            types.StringNull(),
+		Parallelism:       types.Int64Null(),
+		ReplaceOnRotation: types.BoolValue(true),
+	}); diags.HasError() {
+		t.Fatalf("failed to set state: %v", diags)
+	}
+	resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: state.Schema, Raw: state.Raw.Copy()}}
+	(&blindfoldFilesResource{}).ModifyPlan(ctx, resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: state.Schema, Raw: state.Raw},
+		State:  state,
+		Plan:   tfsdk.Plan{Schema: state.Schema, Raw: state.Raw},
+	}, resp)
+	switch {
+	case resp.Diagnostics.HasError():
+		t.Errorf("unexpected error: %v", resp.Diagnostics)
+	case len(resp.RequiresReplace) > 0:
+		t.Errorf("expected no replacement, got %v", resp.RequiresReplace)
+	case !resp.Plan.Raw.Equal(state.Raw):
+		t.Errorf("expected plan to be unchanged, got %s", resp.Plan.Raw)
+	}
+}
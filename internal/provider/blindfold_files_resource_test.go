@@ -0,0 +1,117 @@
+package provider_test
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccBlindfoldFilesResource(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	writeFile := func(name, content string) {
+		if err := os.MkdirAll(filepath.Join(tmpDir, filepath.Dir(name)), 0o700); err != nil {
+			t.Errorf("failed to create directory for %s: %v", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0o600); err != nil {
+			t.Errorf("failed to write plaintext file %s: %v", name, err)
+		}
+	}
+	writeFile("first.json", `{"secret": "first"}`)
+	writeFile("site/second.json", `{"secret": "second"}`)
+	writeFile("site/ignored.txt", "This file does not match the include pattern")
+	writeFile("site/excluded.json", `{"secret": "excluded"}`)
+	config := providerConfig + `
+resource "f5xc_blindfold_files" "test" {
+	directory = "` + tmpDir + `"
+	include = ["**/*.json"]
+	exclude = ["**/excluded.json"]
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+}
+`
+	unchanged := statecheck.CompareValue(compare.ValuesSame())
+	changed := statecheck.CompareValue(compare.ValuesDiffer())
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("f5xc_blindfold_files.test", "id"),
+					resource.TestCheckResourceAttr("f5xc_blindfold_files.test", "sealed.%", "2"),
+					resource.TestCheckResourceAttrSet("f5xc_blindfold_files.test", "sealed.first.json"),
+					resource.TestCheckResourceAttrSet("f5xc_blindfold_files.test", "sealed.site/second.json"),
+					resource.TestCheckResourceAttr("f5xc_blindfold_files.test", "content_hash.%", "2"),
+					resource.TestMatchResourceAttr("f5xc_blindfold_files.test", "content_hash.first.json", regexp.MustCompile(`^[0-9a-f]{32}:[0-9a-f]{64}$`)),
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					unchanged.AddStateValue("f5xc_blindfold_files.test", tfjsonpath.New("sealed").AtMapKey("first.json")),
+					changed.AddStateValue("f5xc_blindfold_files.test", tfjsonpath.New("sealed").AtMapKey("site/second.json")),
+				},
+			},
+			// Changing one file, and adding another, must only blindfold those files.
+			{
+				PreConfig: func() {
+					writeFile("site/second.json", `{"secret": "changed"}`)
+					writeFile("site/deep/third.json", `{"secret": "third"}`)
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("f5xc_blindfold_files.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue("f5xc_blindfold_files.test", tfjsonpath.New("sealed").AtMapKey("site/second.json")),
+						plancheck.ExpectUnknownValue("f5xc_blindfold_files.test", tfjsonpath.New("sealed").AtMapKey("site/deep/third.json")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("f5xc_blindfold_files.test", "sealed.%", "3"),
+					resource.TestCheckResourceAttr("f5xc_blindfold_files.test", "content_hash.%", "3"),
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					unchanged.AddStateValue("f5xc_blindfold_files.test", tfjsonpath.New("sealed").AtMapKey("first.json")),
+					changed.AddStateValue("f5xc_blindfold_files.test", tfjsonpath.New("sealed").AtMapKey("site/second.json")),
+				},
+			},
+			// Removing a file must not blindfold the remaining files.
+			{
+				PreConfig: func() {
+					if err := os.Remove(filepath.Join(tmpDir, "site", "deep", "third.json")); err != nil {
+						t.Errorf("failed to remove plaintext file: %v", err)
+					}
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("f5xc_blindfold_files.test", "sealed.%", "2"),
+					resource.TestCheckNoResourceAttr("f5xc_blindfold_files.test", "sealed.site/deep/third.json"),
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					unchanged.AddStateValue("f5xc_blindfold_files.test", tfjsonpath.New("sealed").AtMapKey("first.json")),
+				},
+			},
+			// A directory that cannot be listed during plan must leave the plan unchanged.
+			{
+				PreConfig: func() {
+					if err := os.RemoveAll(tmpDir); err != nil {
+						t.Errorf("failed to remove plaintext directory: %v", err)
+					}
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
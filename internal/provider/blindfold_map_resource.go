@@ -0,0 +1,499 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/memes/f5xc"
+	"github.com/memes/f5xc/blindfold"
+	"golang.org/x/sync/errgroup"
+)
+
+var (
+	_ resource.Resource                   = &blindfoldMapResource{}
+	_ resource.ResourceWithModifyPlan     = &blindfoldMapResource{}
+	_ resource.ResourceWithConfigure      = &blindfoldMapResource{}
+	_ resource.ResourceWithValidateConfig = &blindfoldMapResource{}
+)
+
+// The default number of entries that will be blindfolded concurrently.
+const defaultBlindfoldParallelism = 4
+
+type blindfoldMapResource struct {
+	timeout time.Duration
+	cache   *blindfoldCache
+}
+
+type blindfoldMapResourceModel struct {
+	ID                types.String        `tfsdk:"id"`
+	Sealed            types.Map           `tfsdk:"sealed"`
+	Plaintext         types.Map           `tfsdk:"plaintext"`
+	PlaintextHashes   types.Map           `tfsdk:"plaintext_hashes"`
+	PolicyDocument    policyDocumentModel `tfsdk:"policy_document"`
+	Vesctl            types.String        `tfsdk:"vesctl"`
+	Parallelism       types.Int64         `tfsdk:"parallelism"`
+	ReplaceOnRotation types.Bool          `tfsdk:"replace_on_rotation"`
+}
+
+// NewBlindfoldMapResource creates a new blindfold map Terraform resource and returns a pointer to it.
+func NewBlindfoldMapResource() resource.Resource {
+	return &blindfoldMapResource{}
+}
+
+// Implement the Metadata function for Resource interface.
+func (r *blindfoldMapResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_blindfold_map"
+}
+
+// Implement the Schema function for Resource interface. Blindfold map resources accept a write-only map of plaintext
+// values that will be blindfolded with the same secret policy document, and a salted hash of each value is stored in
+// state so that only changed entries are blindfolded again.
+func (r *blindfoldMapResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Generates blindfolded secrets from a map of base64 encoded source strings, using a " +
+			"single secret policy document.\n\n" +
+			"NOTE: The `plaintext` attribute is write-only and requires Terraform 1.11 or later; a salted hash of each " +
+			"entry is stored in state instead so that only the entries that have changed will be blindfolded again.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The computed resource identifier for the blindfolded secrets.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"sealed": schema.MapAttribute{
+				Description: "A map of the base64 encoded, sealed data resulting from a blindfold of each plaintext entry.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"plaintext": schema.MapAttribute{
+				MarkdownDescription: "A map of base64 encoded plaintext data that will be blindfolded, as a write-only " +
+					"value that is never stored in Terraform plan or state.",
+				ElementType: types.StringType,
+				Required:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+			},
+			"plaintext_hashes": schema.MapAttribute{
+				MarkdownDescription: "A map of salted SHA-256 hashes of each `plaintext` entry that was blindfolded, " +
+					"used to detect changes to the plaintext without storing it in state.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"policy_document": schema.SingleNestedAttribute{
+				Required: true,
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Description: "The name of the F5XC PolicyDocument to use for blindfold.",
+						Required:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"namespace": schema.StringAttribute{
+						Description: "The namespace of the F5XC PolicyDocument to use for blindfold.",
+						Required:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+				},
+			},
+			"vesctl": schema.StringAttribute{
+				MarkdownDescription: "The path to `vesctl` binary to use for blindfolding. If " +
+					"unspecified, the first vesctl binary found in PATH will be used",
+				Optional: true,
+			},
+			"parallelism": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of entries that will be blindfolded "+
+					"concurrently. Defaults to %d.", defaultBlindfoldParallelism),
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(defaultBlindfoldParallelism),
+			},
+			"replace_on_rotation": schema.BoolAttribute{
+				MarkdownDescription: "If true, the default, all entries will be blindfolded again when the tenant's " +
+					"public key is rotated or the secret policy document is changed. If false, a warning will be " +
+					"reported instead.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+// Implement the Configure function for Resource interface.
+func (r *blindfoldMapResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*f5XCConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *f5XCConfig, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.timeout = cfg.timeout
+	r.cache = cfg.cache
+}
+
+// Implement the ValidateConfig function for ResourceWithValidateConfig interface. The parallelism must be positive.
+func (r *blindfoldMapResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) { //nolint:gocritic // Provider interface passes ValidateConfigRequest by value.
+	resp.Diagnostics.Append(validateParallelism(ctx, &req.Config)...)
+}
+
+// Implement the Create function for Resource interface. Every entry in the plaintext map is blindfolded.
+func (r *blindfoldMapResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) { //nolint:gocritic // Provider interface passes CreateRequest by value.
+	tflog.Info(ctx, "Creating blindfold map resource")
+	var model blindfoldMapResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "policy_doc_name", model.PolicyDocument.Name.ValueString())
+	ctx = tflog.SetField(ctx, "policy_doc_namespace", model.PolicyDocument.Namespace.ValueString())
+	ctx = tflog.SetField(ctx, "vesctl", model.Vesctl.ValueString())
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error computing id",
+			"Failed to compute a new id for the resource, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	model.ID = types.StringValue(id.String())
+
+	// Write-only values are never included in the plan and must be retrieved from config.
+	var plaintext map[string]string
+	diags = req.Config.GetAttribute(ctx, path.Root("plaintext"), &plaintext)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pubKey, policyDoc, diags := fetchBlindfoldInputs(ctx, r.cache, &model.PolicyDocument)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	rotation, err := newBlindfoldPrivateState(pubKey, policyDoc)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error recording PublicKey version",
+			"Failed to record PublicKey version and SecretPolicyDocument fingerprint, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(rotation.set(ctx, resp.Private)...)
+
+	sealed := map[string]string{}
+	hashes := map[string]string{}
+	resp.Diagnostics.Append(r.seal(ctx, &model, pubKey, policyDoc, plaintext, sealed, hashes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(model.setSealed(ctx, sealed, hashes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Implement the Read function for Resource interface. The sealed values cannot be verified, but the public key version
+// and secret policy document that were used to blindfold the secrets are compared to the current values in F5XC so that
+// the secrets can be blindfolded again if either has changed.
+func (r *blindfoldMapResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) { //nolint:gocritic // Provider interface passes ReadRequest by value.
+	tflog.Info(ctx, "Reading blindfold map resource")
+	var model blindfoldMapResourceModel
+	diags := req.State.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "policy_doc_name", model.PolicyDocument.Name.ValueString())
+	ctx = tflog.SetField(ctx, "policy_doc_namespace", model.PolicyDocument.Namespace.ValueString())
+
+	checkBlindfoldRotation(ctx, r.cache, []policyDocumentModel{model.PolicyDocument}, model.ReplaceOnRotation.ValueBool(), req.Private, resp)
+}
+
+// Implement the ModifyPlan function for ResourceWithModifyPlan interface. The resource will be replaced if Read has
+// detected that the public key or secret policy document has changed since the secrets were blindfolded; otherwise the
+// sealed values of new or changed plaintext entries are marked as unknown so that Update will blindfold them again.
+func (r *blindfoldMapResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) { //nolint:gocritic // Provider interface passes ModifyPlanRequest by value.
+	planBlindfoldRotation(ctx, &req, resp)
+	// Nothing more to do when the resource is being created, replaced, or destroyed.
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || len(resp.RequiresReplace) > 0 {
+		return
+	}
+	var plaintext types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("plaintext"), &plaintext)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plaintext.IsUnknown() {
+		tflog.Debug(ctx, "Plaintext is unknown during plan, all entries will be blindfolded again")
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("sealed"), types.MapUnknown(types.StringType))...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("plaintext_hashes"), types.MapUnknown(types.StringType))...)
+		return
+	}
+	var entries map[string]types.String
+	resp.Diagnostics.Append(plaintext.ElementsAs(ctx, &entries, false)...)
+	var recordedSealed, recordedHashes map[string]string
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("sealed"), &recordedSealed)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("plaintext_hashes"), &recordedHashes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plannedSealed := make(map[string]attr.Value, len(entries))
+	plannedHashes := make(map[string]attr.Value, len(entries))
+	for key, value := range entries {
+		sealed, hasSealed := recordedSealed[key]
+		hash, hasHash := recordedHashes[key]
+		if hasSealed && hasHash && !value.IsUnknown() {
+			matches, err := plaintextHashMatches(hash, value.ValueString())
+			if err != nil {
+				tflog.Warn(ctx, "Unable to compare plaintext entry with recorded hash", map[string]any{"key": key, "error": err.Error()})
+			}
+			if matches {
+				plannedSealed[key] = types.StringValue(sealed)
+				plannedHashes[key] = types.StringValue(hash)
+				continue
+			}
+		}
+		tflog.Debug(ctx, "Plaintext entry is new or has changed", map[string]any{"key": key})
+		plannedSealed[key] = types.StringUnknown()
+		plannedHashes[key] = types.StringUnknown()
+	}
+	sealedMap, diags := types.MapValue(types.StringType, plannedSealed)
+	resp.Diagnostics.Append(diags...)
+	hashesMap, diags := types.MapValue(types.StringType, plannedHashes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("sealed"), sealedMap)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("plaintext_hashes"), hashesMap)...)
+}
+
+// Implement the Update function for Resource interface. Only the plaintext entries that have been marked as unknown by
+// ModifyPlan are blindfolded again, and entries that have been removed from plaintext are removed from state.
+func (r *blindfoldMapResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) { //nolint:gocritic // Provider interface passes UpdateRequest by value.
+	tflog.Info(ctx, "Updating blindfold map resource")
+	var model blindfoldMapResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "policy_doc_name", model.PolicyDocument.Name.ValueString())
+	ctx = tflog.SetField(ctx, "policy_doc_namespace", model.PolicyDocument.Namespace.ValueString())
+	ctx = tflog.SetField(ctx, "vesctl", model.Vesctl.ValueString())
+
+	var plaintext map[string]string
+	diags = req.Config.GetAttribute(ctx, path.Root("plaintext"), &plaintext)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sealed := map[string]string{}
+	hashes := map[string]string{}
+	changed := map[string]string{}
+	var plannedSealed, plannedHashes map[string]types.String
+	if !model.Sealed.IsUnknown() {
+		resp.Diagnostics.Append(model.Sealed.ElementsAs(ctx, &plannedSealed, false)...)
+	}
+	if !model.PlaintextHashes.IsUnknown() {
+		resp.Diagnostics.Append(model.PlaintextHashes.ElementsAs(ctx, &plannedHashes, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for key, value := range plaintext {
+		if s, ok := plannedSealed[key]; ok && !s.IsUnknown() {
+			sealed[key] = s.ValueString()
+			hashes[key] = plannedHashes[key].ValueString()
+			continue
+		}
+		changed[key] = value
+	}
+
+	if len(changed) > 0 {
+		pubKey, policyDoc, diags := fetchBlindfoldInputs(ctx, r.cache, &model.PolicyDocument)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(r.seal(ctx, &model, pubKey, policyDoc, changed, sealed, hashes)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	resp.Diagnostics.Append(model.setSealed(ctx, sealed, hashes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Implement the Delete function for Resource interface. Blindfold resources do not create any state to clean up, so this
+// function does nothing. Terraform state will be deleted as long as the function does not add diagnostics to the response.
+func (r *blindfoldMapResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) { //nolint:gocritic // Provider interface passes DeleteRequest by value.
+}
+
+// Returns diagnostics if the configured parallelism is less than 1.
+func validateParallelism(ctx context.Context, config *tfsdk.Config) diag.Diagnostics {
+	var parallelism types.Int64
+	diags := config.GetAttribute(ctx, path.Root("parallelism"), &parallelism)
+	if diags.HasError() || parallelism.IsNull() || parallelism.IsUnknown() {
+		return diags
+	}
+	if parallelism.ValueInt64() < 1 {
+		diags.AddAttributeError(
+			path.Root("parallelism"),
+			"Invalid parallelism",
+			fmt.Sprintf("The parallelism attribute must be at least 1, got: %d", parallelism.ValueInt64()),
+		)
+	}
+	return diags
+}
+
+// Returns the tenant public key and the secret policy document that will be used to blindfold secrets.
+func fetchBlindfoldInputs(ctx context.Context, cache *blindfoldCache, policy *policyDocumentModel) (*f5xc.PublicKey, *f5xc.SecretPolicyDocument, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	tflog.Debug(ctx, "Fetching Public Key")
+	pubKey, err := cache.publicKey(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error retrieving PublicKey",
+			"Could not retrieve PublicKey, unexpected error: "+err.Error(),
+		)
+		return nil, nil, diags
+	}
+
+	tflog.Debug(ctx, "Fetching Secret Policy Document")
+	policyDoc, err := cache.secretPolicyDocument(ctx, policy.Name.ValueString(), policy.Namespace.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error retrieving SecretPolicyDocument",
+			"Could not retrieve SecretPolicyDocument, unexpected error: "+err.Error(),
+		)
+		return nil, nil, diags
+	}
+	return pubKey, policyDoc, diags
+}
+
+// Calls seal for each of the keys using a pool of at most parallelism workers, and returns a map of key to the sealed
+// value. All outstanding calls are cancelled if any call to seal fails.
+func sealConcurrently(ctx context.Context, parallelism int64, keys []string, seal func(context.Context, string) (string, error)) (map[string]string, error) {
+	var mu sync.Mutex
+	sealed := make(map[string]string, len(keys))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(int(parallelism))
+	for _, key := range keys {
+		group.Go(func() error {
+			tflog.Debug(groupCtx, "Executing blindfold", map[string]any{"key": key})
+			value, err := seal(groupCtx, key)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			sealed[key] = value
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, fmt.Errorf("failed to blindfold: %w", err)
+	}
+	return sealed, nil
+}
+
+// Blindfolds each of the plaintext entries, adding the sealed value and a salted hash of the plaintext for each entry to
+// the sealed and hashes maps.
+func (r *blindfoldMapResource) seal(ctx context.Context, model *blindfoldMapResourceModel, pubKey *f5xc.PublicKey, policyDoc *f5xc.SecretPolicyDocument, plaintext, sealed, hashes map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	keys := make([]string, 0, len(plaintext))
+	for key, value := range plaintext {
+		hash, err := newPlaintextHash(value)
+		if err != nil {
+			diags.AddError(
+				"Error hashing plaintext",
+				"Failed to compute a salted hash of plaintext, unexpected error: "+err.Error(),
+			)
+			return diags
+		}
+		hashes[key] = hash
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	values, err := sealConcurrently(ctx, model.Parallelism.ValueInt64(), keys, func(ctx context.Context, key string) (string, error) {
+		decoded, err := base64.StdEncoding.DecodeString(plaintext[key])
+		if err != nil {
+			return "", fmt.Errorf("failed to decode base64 plaintext for %q: %w", key, err)
+		}
+		clientCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+		value, err := blindfold.Seal(clientCtx, model.Vesctl.ValueString(), decoded, pubKey, policyDoc)
+		if err != nil {
+			return "", fmt.Errorf("failed to blindfold %q: %w", key, err)
+		}
+		return string(value), nil
+	})
+	if err != nil {
+		diags.AddError(
+			"Error blindfolding data",
+			"Failed to blindfold data, unexpected error: "+err.Error(),
+		)
+		return diags
+	}
+	for key, value := range values {
+		sealed[key] = value
+	}
+	return diags
+}
+
+// Sets the sealed and plaintext_hashes attributes of the model.
+func (m *blindfoldMapResourceModel) setSealed(ctx context.Context, sealed, hashes map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	sealedMap, d := types.MapValueFrom(ctx, types.StringType, sealed)
+	diags.Append(d...)
+	hashesMap, d := types.MapValueFrom(ctx, types.StringType, hashes)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	m.Sealed = sealedMap
+	m.PlaintextHashes = hashesMap
+	m.Plaintext = types.MapNull(types.StringType)
+	return diags
+}
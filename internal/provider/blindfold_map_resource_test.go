@@ -0,0 +1,106 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccBlindfoldMapResource(t *testing.T) {
+	t.Parallel()
+	unchanged := statecheck.CompareValue(compare.ValuesSame())
+	changed := statecheck.CompareValue(compare.ValuesDiffer())
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "f5xc_blindfold_map" "test" {
+	plaintext = {
+		first  = "VGhpcyBpcyBhIHRlc3Q="
+		second = "VGhpcyBpcyBhbm90aGVyIHRlc3Q="
+	}
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("f5xc_blindfold_map.test", "id"),
+					resource.TestCheckNoResourceAttr("f5xc_blindfold_map.test", "plaintext"),
+					resource.TestCheckResourceAttr("f5xc_blindfold_map.test", "parallelism", "4"),
+					resource.TestCheckResourceAttr("f5xc_blindfold_map.test", "sealed.%", "2"),
+					resource.TestCheckResourceAttrSet("f5xc_blindfold_map.test", "sealed.first"),
+					resource.TestCheckResourceAttrSet("f5xc_blindfold_map.test", "sealed.second"),
+					resource.TestCheckResourceAttr("f5xc_blindfold_map.test", "plaintext_hashes.%", "2"),
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					unchanged.AddStateValue("f5xc_blindfold_map.test", tfjsonpath.New("sealed").AtMapKey("first")),
+					changed.AddStateValue("f5xc_blindfold_map.test", tfjsonpath.New("sealed").AtMapKey("second")),
+				},
+			},
+			// Changing one entry, and adding another, must only blindfold those entries.
+			{
+				Config: providerConfig + `
+resource "f5xc_blindfold_map" "test" {
+	plaintext = {
+		first  = "VGhpcyBpcyBhIHRlc3Q="
+		second = "VGhpcyBpcyBhIGNoYW5nZWQgdGVzdA=="
+		third  = "VGhpcyBpcyBhIG5ldyB0ZXN0"
+	}
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+	parallelism = 2
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("f5xc_blindfold_map.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue("f5xc_blindfold_map.test", tfjsonpath.New("sealed").AtMapKey("second")),
+						plancheck.ExpectUnknownValue("f5xc_blindfold_map.test", tfjsonpath.New("sealed").AtMapKey("third")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("f5xc_blindfold_map.test", "sealed.%", "3"),
+					resource.TestCheckResourceAttr("f5xc_blindfold_map.test", "plaintext_hashes.%", "3"),
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					unchanged.AddStateValue("f5xc_blindfold_map.test", tfjsonpath.New("sealed").AtMapKey("first")),
+					changed.AddStateValue("f5xc_blindfold_map.test", tfjsonpath.New("sealed").AtMapKey("second")),
+				},
+			},
+			// Removing an entry must not blindfold the remaining entries.
+			{
+				Config: providerConfig + `
+resource "f5xc_blindfold_map" "test" {
+	plaintext = {
+		first  = "VGhpcyBpcyBhIHRlc3Q="
+	}
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("f5xc_blindfold_map.test", "sealed.%", "1"),
+					resource.TestCheckResourceAttr("f5xc_blindfold_map.test", "plaintext_hashes.%", "1"),
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					unchanged.AddStateValue("f5xc_blindfold_map.test", tfjsonpath.New("sealed").AtMapKey("first")),
+				},
+			},
+		},
+	})
+}
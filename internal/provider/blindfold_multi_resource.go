@@ -0,0 +1,496 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/memes/f5xc"
+	"github.com/memes/f5xc/blindfold"
+)
+
+var (
+	_ resource.Resource                   = &blindfoldMultiResource{}
+	_ resource.ResourceWithModifyPlan     = &blindfoldMultiResource{}
+	_ resource.ResourceWithConfigure      = &blindfoldMultiResource{}
+	_ resource.ResourceWithValidateConfig = &blindfoldMultiResource{}
+)
+
+type blindfoldMultiResource struct {
+	timeout time.Duration
+	cache   *blindfoldCache
+}
+
+type blindfoldMultiResourceModel struct {
+	ID                types.String          `tfsdk:"id"`
+	Sealed            types.Map             `tfsdk:"sealed"`
+	Plaintext         types.String          `tfsdk:"plaintext"`
+	PlaintextHash     types.String          `tfsdk:"plaintext_hash"`
+	PolicyDocuments   []policyDocumentModel `tfsdk:"policy_documents"`
+	Vesctl            types.String          `tfsdk:"vesctl"`
+	ReplaceOnRotation types.Bool            `tfsdk:"replace_on_rotation"`
+}
+
+// NewBlindfoldMultiResource creates a new blindfold multi Terraform resource and returns a pointer to it.
+func NewBlindfoldMultiResource() resource.Resource {
+	return &blindfoldMultiResource{}
+}
+
+// Implement the Metadata function for Resource interface.
+func (r *blindfoldMultiResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_blindfold_multi"
+}
+
+// Implement the Schema function for Resource interface. Blindfold multi resources accept write-only plaintext data and
+// a list of name+namespace references to secret policy documents; the plaintext is blindfolded once for each policy.
+func (r *blindfoldMultiResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Generates blindfolded secrets from a base64 encoded source string, sealed once for each " +
+			"of a list of secret policy documents.\n\n" +
+			"NOTE: The `plaintext` attribute is write-only and requires Terraform 1.11 or later; a salted hash of " +
+			"`plaintext` is stored in state instead so that a change to the source value will blindfold it again.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The computed resource identifier for the blindfolded secrets.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"sealed": schema.MapAttribute{
+				Description: "A map of the namespace/name of each secret policy document to the base64 encoded, sealed " +
+					"data resulting from a blindfold with that policy.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"plaintext": schema.StringAttribute{
+				MarkdownDescription: "The base64 encoded plaintext data that will be blindfolded, as a write-only value " +
+					"that is never stored in Terraform plan or state.",
+				Required:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"plaintext_hash": schema.StringAttribute{
+				MarkdownDescription: "A salted SHA-256 hash of the `plaintext` value that was blindfolded, used to " +
+					"detect changes to the plaintext without storing it in state.",
+				Computed: true,
+			},
+			"policy_documents": schema.ListNestedAttribute{
+				MarkdownDescription: "The F5XC secret policy documents to use for blindfold. Adding a policy document " +
+					"will only blindfold the plaintext for the new policy.",
+				Required: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The name of the F5XC PolicyDocument to use for blindfold.",
+							Required:    true,
+						},
+						"namespace": schema.StringAttribute{
+							Description: "The namespace of the F5XC PolicyDocument to use for blindfold.",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"vesctl": schema.StringAttribute{
+				MarkdownDescription: "The path to `vesctl` binary to use for blindfolding. If " +
+					"unspecified, the first vesctl binary found in PATH will be used",
+				Optional: true,
+			},
+			"replace_on_rotation": schema.BoolAttribute{
+				MarkdownDescription: "If true, the default, the secret will be blindfolded again when the tenant's " +
+					"public key is rotated or any of the secret policy documents are changed. If false, a warning will " +
+					"be reported instead.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+// Implement the Configure function for Resource interface.
+func (r *blindfoldMultiResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*f5XCConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *f5XCConfig, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.timeout = cfg.timeout
+	r.cache = cfg.cache
+}
+
+// Implement the ValidateConfig function for ResourceWithValidateConfig interface. At least one policy document must be
+// provided, and each may only be referenced once.
+func (r *blindfoldMultiResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) { //nolint:gocritic // Provider interface passes ValidateConfigRequest by value.
+	var policies types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("policy_documents"), &policies)...)
+	if resp.Diagnostics.HasError() || policies.IsNull() || policies.IsUnknown() {
+		return
+	}
+	if len(policies.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("policy_documents"),
+			"Missing policy_documents",
+			"At least one secret policy document must be provided.",
+		)
+		return
+	}
+	var models []types.Object
+	resp.Diagnostics.Append(policies.ElementsAs(ctx, &models, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	seen := map[string]bool{}
+	for i, obj := range models {
+		if obj.IsUnknown() {
+			continue
+		}
+		var policy policyDocumentModel
+		resp.Diagnostics.Append(obj.As(ctx, &policy, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if policy.Name.IsUnknown() || policy.Namespace.IsUnknown() {
+			continue
+		}
+		key := policy.key()
+		if seen[key] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("policy_documents").AtListIndex(i),
+				"Duplicate policy document",
+				"The secret policy document "+key+" is referenced more than once.",
+			)
+		}
+		seen[key] = true
+	}
+}
+
+// Implement the Create function for Resource interface. The plaintext is blindfolded with every policy document.
+func (r *blindfoldMultiResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) { //nolint:gocritic // Provider interface passes CreateRequest by value.
+	tflog.Info(ctx, "Creating blindfold multi resource")
+	var model blindfoldMultiResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "vesctl", model.Vesctl.ValueString())
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error computing id",
+			"Failed to compute a new id for the resource, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	model.ID = types.StringValue(id.String())
+
+	// Write-only values are never included in the plan and must be retrieved from config.
+	var plaintext types.String
+	diags = req.Config.GetAttribute(ctx, path.Root("plaintext"), &plaintext)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	hash, err := newPlaintextHash(plaintext.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error hashing plaintext",
+			"Failed to compute a salted hash of plaintext, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	model.PlaintextHash = types.StringValue(hash)
+
+	sealed := map[string]string{}
+	resp.Diagnostics.Append(r.seal(ctx, &model, plaintext.ValueString(), model.policyKeys(), sealed, resp.Private)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(model.setSealed(ctx, sealed)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Implement the Read function for Resource interface. The sealed values cannot be verified, but the public key version
+// and secret policy documents that were used to blindfold the secret are compared to the current values in F5XC so that
+// the secret can be blindfolded again if any have changed.
+func (r *blindfoldMultiResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) { //nolint:gocritic // Provider interface passes ReadRequest by value.
+	tflog.Info(ctx, "Reading blindfold multi resource")
+	var model blindfoldMultiResourceModel
+	diags := req.State.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checkBlindfoldRotation(ctx, r.cache, model.sortedPolicyDocuments(), model.ReplaceOnRotation.ValueBool(), req.Private, resp)
+}
+
+// Implement the ModifyPlan function for ResourceWithModifyPlan interface. The resource will be replaced if Read has
+// detected that the public key or any secret policy document has changed since the secret was blindfolded. Otherwise,
+// every sealed value is marked as unknown if the plaintext has changed, or only the sealed values of new policy
+// documents are marked as unknown, so that Update will blindfold them.
+func (r *blindfoldMultiResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) { //nolint:gocritic // Provider interface passes ModifyPlanRequest by value.
+	planBlindfoldRotation(ctx, &req, resp)
+	// Nothing more to do when the resource is being created, replaced, or destroyed.
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || len(resp.RequiresReplace) > 0 {
+		return
+	}
+	var plaintext, recorded types.String
+	var policies types.List
+	var recordedSealed map[string]string
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("plaintext"), &plaintext)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("policy_documents"), &policies)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("plaintext_hash"), &recorded)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("sealed"), &recordedSealed)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var models []policyDocumentModel
+	if !policies.IsUnknown() {
+		resp.Diagnostics.Append(policies.ElementsAs(ctx, &models, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	unknown := policies.IsUnknown() || slices.ContainsFunc(models, func(m policyDocumentModel) bool {
+		return m.Name.IsUnknown() || m.Namespace.IsUnknown()
+	})
+
+	changed := plaintext.IsUnknown()
+	if !changed && !recorded.IsNull() {
+		matches, err := plaintextHashMatches(recorded.ValueString(), plaintext.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("plaintext_hash"),
+				"Unable to compare plaintext with recorded hash",
+				"The recorded plaintext hash could not be parsed and the secret will be blindfolded again, unexpected "+
+					"error: "+err.Error(),
+			)
+		}
+		changed = !matches
+	}
+	if changed {
+		tflog.Info(ctx, "Plaintext has changed, every policy will be blindfolded again")
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("plaintext_hash"), types.StringUnknown())...)
+	} else {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("plaintext_hash"), recorded)...)
+	}
+	if unknown {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("sealed"), types.MapUnknown(types.StringType))...)
+		return
+	}
+
+	plannedSealed := make(map[string]attr.Value, len(models))
+	for i := range models {
+		key := models[i].key()
+		if sealed, ok := recordedSealed[key]; ok && !changed {
+			plannedSealed[key] = types.StringValue(sealed)
+			continue
+		}
+		plannedSealed[key] = types.StringUnknown()
+	}
+	sealedMap, diags := types.MapValue(types.StringType, plannedSealed)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("sealed"), sealedMap)...)
+}
+
+// Implement the Update function for Resource interface. Only the policies whose sealed values have been marked as
+// unknown by ModifyPlan are blindfolded, and policies that have been removed are removed from state.
+func (r *blindfoldMultiResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) { //nolint:gocritic // Provider interface passes UpdateRequest by value.
+	tflog.Info(ctx, "Updating blindfold multi resource")
+	var model blindfoldMultiResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "vesctl", model.Vesctl.ValueString())
+
+	var plaintext types.String
+	diags = req.Config.GetAttribute(ctx, path.Root("plaintext"), &plaintext)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if model.PlaintextHash.IsUnknown() {
+		hash, err := newPlaintextHash(plaintext.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error hashing plaintext",
+				"Failed to compute a salted hash of plaintext, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		model.PlaintextHash = types.StringValue(hash)
+	}
+
+	var plannedSealed map[string]types.String
+	if !model.Sealed.IsUnknown() {
+		resp.Diagnostics.Append(model.Sealed.ElementsAs(ctx, &plannedSealed, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	sealed := map[string]string{}
+	var changed []string
+	for _, key := range model.policyKeys() {
+		if value, ok := plannedSealed[key]; ok && !value.IsUnknown() {
+			sealed[key] = value.ValueString()
+			continue
+		}
+		changed = append(changed, key)
+	}
+	if len(changed) > 0 {
+		resp.Diagnostics.Append(r.seal(ctx, &model, plaintext.ValueString(), changed, sealed, resp.Private)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	resp.Diagnostics.Append(model.setSealed(ctx, sealed)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Implement the Delete function for Resource interface. Blindfold resources do not create any state to clean up, so this
+// function does nothing. Terraform state will be deleted as long as the function does not add diagnostics to the response.
+func (r *blindfoldMultiResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) { //nolint:gocritic // Provider interface passes DeleteRequest by value.
+}
+
+// Blindfolds the plaintext with each of the policy documents identified by keys, adding the sealed value to the sealed
+// map. The public key is fetched once, and the public key version and fingerprint of every policy document are recorded
+// in private state.
+func (r *blindfoldMultiResource) seal(ctx context.Context, model *blindfoldMultiResourceModel, encoded string, keys []string, sealed map[string]string, private privateStateSetter) diag.Diagnostics {
+	var diags diag.Diagnostics
+	tflog.Debug(ctx, "Decoding plaintext value from base64")
+	plaintext, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		diags.AddError(
+			"Error decoding Base64 plaintext",
+			"Failed to decode base64 plaintext to byte array, unexpected error: "+err.Error(),
+		)
+		return diags
+	}
+
+	tflog.Debug(ctx, "Fetching Public Key")
+	pubKey, err := r.cache.publicKey(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error retrieving PublicKey",
+			"Could not retrieve PublicKey, unexpected error: "+err.Error(),
+		)
+		return diags
+	}
+	policies := model.sortedPolicyDocuments()
+	policyDocs := make(map[string]*f5xc.SecretPolicyDocument, len(policies))
+	fingerprinted := make([]*f5xc.SecretPolicyDocument, 0, len(policies))
+	for i := range policies {
+		tflog.Debug(ctx, "Fetching Secret Policy Document", map[string]any{"policy_doc": policies[i].key()})
+		policyDoc, err := r.cache.secretPolicyDocument(ctx, policies[i].Name.ValueString(), policies[i].Namespace.ValueString())
+		if err != nil {
+			diags.AddError(
+				"Error retrieving SecretPolicyDocument",
+				"Could not retrieve SecretPolicyDocument "+policies[i].key()+", unexpected error: "+err.Error(),
+			)
+			return diags
+		}
+		policyDocs[policies[i].key()] = policyDoc
+		fingerprinted = append(fingerprinted, policyDoc)
+	}
+	rotation, err := newBlindfoldPrivateState(pubKey, fingerprinted...)
+	if err != nil {
+		diags.AddError(
+			"Error recording PublicKey version",
+			"Failed to record PublicKey version and SecretPolicyDocument fingerprint, unexpected error: "+err.Error(),
+		)
+		return diags
+	}
+	diags.Append(rotation.set(ctx, private)...)
+
+	values, err := sealConcurrently(ctx, defaultBlindfoldParallelism, keys, func(ctx context.Context, key string) (string, error) {
+		clientCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+		value, err := blindfold.Seal(clientCtx, model.Vesctl.ValueString(), plaintext, pubKey, policyDocs[key])
+		if err != nil {
+			return "", fmt.Errorf("failed to blindfold with %s: %w", key, err)
+		}
+		return string(value), nil
+	})
+	if err != nil {
+		diags.AddError(
+			"Error blindfolding data",
+			"Failed to blindfold data, unexpected error: "+err.Error(),
+		)
+		return diags
+	}
+	for key, value := range values {
+		sealed[key] = value
+	}
+	return diags
+}
+
+// Returns the policy documents sorted by namespace/name, so that the recorded fingerprint does not depend on the order
+// in which they are listed.
+func (m *blindfoldMultiResourceModel) sortedPolicyDocuments() []policyDocumentModel {
+	policies := slices.Clone(m.PolicyDocuments)
+	slices.SortFunc(policies, func(a, b policyDocumentModel) int {
+		return strings.Compare(a.key(), b.key())
+	})
+	return policies
+}
+
+// Returns the namespace/name keys of the policy documents.
+func (m *blindfoldMultiResourceModel) policyKeys() []string {
+	keys := make([]string, 0, len(m.PolicyDocuments))
+	for i := range m.PolicyDocuments {
+		keys = append(keys, m.PolicyDocuments[i].key())
+	}
+	return keys
+}
+
+// Sets the sealed attribute of the model.
+func (m *blindfoldMultiResourceModel) setSealed(ctx context.Context, sealed map[string]string) diag.Diagnostics {
+	sealedMap, diags := types.MapValueFrom(ctx, types.StringType, sealed)
+	if diags.HasError() {
+		return diags
+	}
+	m.Sealed = sealedMap
+	m.Plaintext = types.StringNull()
+	return diags
+}
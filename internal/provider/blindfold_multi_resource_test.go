@@ -0,0 +1,99 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccBlindfoldMultiResource(t *testing.T) {
+	t.Parallel()
+	name := acctest.RandomWithPrefix("tf-acc")
+	policyConfig := `
+resource "f5xc_secret_policy_rule" "test" {
+	name = "` + name + `"
+	namespace = "shared"
+	action = "ALLOW"
+	client_name = "ves-io-system"
+}
+
+resource "f5xc_secret_policy" "test" {
+	name = "` + name + `"
+	namespace = "shared"
+	rules = [
+		{
+			name = f5xc_secret_policy_rule.test.name
+			namespace = f5xc_secret_policy_rule.test.namespace
+		},
+	]
+}
+`
+	unchanged := statecheck.CompareValue(compare.ValuesSame())
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + policyConfig + `
+resource "f5xc_blindfold_multi" "test" {
+	plaintext = "VGhpcyBpcyBhIHRlc3Q="
+	policy_documents = [
+		{
+			name = "ves-io-allow-volterra"
+			namespace = "shared"
+		},
+	]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("f5xc_blindfold_multi.test", "id"),
+					resource.TestCheckNoResourceAttr("f5xc_blindfold_multi.test", "plaintext"),
+					resource.TestCheckResourceAttrSet("f5xc_blindfold_multi.test", "plaintext_hash"),
+					resource.TestCheckResourceAttr("f5xc_blindfold_multi.test", "sealed.%", "1"),
+					resource.TestCheckResourceAttrSet("f5xc_blindfold_multi.test", "sealed.shared/ves-io-allow-volterra"),
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					unchanged.AddStateValue("f5xc_blindfold_multi.test", tfjsonpath.New("sealed").AtMapKey("shared/ves-io-allow-volterra")),
+				},
+			},
+			// Adding a policy document must only blindfold the plaintext for the new policy.
+			{
+				Config: providerConfig + policyConfig + `
+resource "f5xc_blindfold_multi" "test" {
+	plaintext = "VGhpcyBpcyBhIHRlc3Q="
+	policy_documents = [
+		{
+			name = "ves-io-allow-volterra"
+			namespace = "shared"
+		},
+		{
+			name = f5xc_secret_policy.test.name
+			namespace = f5xc_secret_policy.test.namespace
+		},
+	]
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("f5xc_blindfold_multi.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("f5xc_blindfold_multi.test", "sealed.%", "2"),
+					resource.TestCheckResourceAttrSet("f5xc_blindfold_multi.test", "sealed.shared/"+name),
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					unchanged.AddStateValue("f5xc_blindfold_multi.test", tfjsonpath.New("sealed").AtMapKey("shared/ves-io-allow-volterra")),
+				},
+			},
+		},
+	})
+}
@@ -2,30 +2,55 @@ package provider
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
-	"net/http"
+	"io"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/memes/f5xc"
 	"github.com/memes/f5xc/blindfold"
 )
 
 var (
-	_ resource.Resource              = &blindfoldResource{}
-	_ resource.ResourceWithConfigure = &blindfoldResource{}
+	_ resource.Resource                   = &blindfoldResource{}
+	_ resource.ResourceWithModifyPlan     = &blindfoldResource{}
+	_ resource.ResourceWithConfigure      = &blindfoldResource{}
+	_ resource.ResourceWithValidateConfig = &blindfoldResource{}
+	_ resource.ResourceWithUpgradeState   = &blindfoldResource{}
+	_ resource.ResourceWithImportState    = &blindfoldResource{}
 )
 
+// errInvalidPlaintextHash is returned when a recorded plaintext hash cannot be parsed.
+var errInvalidPlaintextHash = errors.New("invalid plaintext hash")
+
+// The number of random bytes used to salt the plaintext hash.
+const plaintextHashSaltLength = 16
+
 type blindfoldResource struct {
-	client  *http.Client
 	timeout time.Duration
+	cache   *blindfoldCache
 }
 
 type policyDocumentModel struct {
@@ -33,7 +58,35 @@ type policyDocumentModel struct {
 	Namespace types.String `tfsdk:"namespace"`
 }
 
+// Returns the namespace/name key of the referenced secret policy document.
+func (m *policyDocumentModel) key() string {
+	return m.Namespace.ValueString() + "/" + m.Name.ValueString()
+}
+
 type blindfoldResourceModel struct {
+	ID                 types.String        `tfsdk:"id"`
+	Sealed             types.String        `tfsdk:"sealed"`
+	Location           types.String        `tfsdk:"location"`
+	SecretInfoJSON     types.String        `tfsdk:"secret_info_json"`
+	DecryptionProvider types.String        `tfsdk:"decryption_provider"`
+	StoreProvider      types.String        `tfsdk:"store_provider"`
+	Plaintext          types.String        `tfsdk:"plaintext"`
+	PlaintextWO        types.String        `tfsdk:"plaintext_wo"`
+	PlaintextWOVersion types.Int64         `tfsdk:"plaintext_wo_version"`
+	PlaintextHash      types.String        `tfsdk:"plaintext_hash"`
+	PlaintextEncoding  types.String        `tfsdk:"plaintext_encoding"`
+	Source             types.Object        `tfsdk:"source"`
+	PolicyDocument     policyDocumentModel `tfsdk:"policy_document"`
+	Vesctl             types.String        `tfsdk:"vesctl"`
+	Triggers           types.Map           `tfsdk:"triggers"`
+	RotationPeriod     types.String        `tfsdk:"rotation_period"`
+	CreatedAt          types.String        `tfsdk:"created_at"`
+	ReplaceOnRotation  types.Bool          `tfsdk:"replace_on_rotation"`
+}
+
+// blindfoldResourceModelV0 is the model of version 0 of the blindfold resource schema, which did not record a hash of
+// the plaintext.
+type blindfoldResourceModelV0 struct {
 	ID             types.String        `tfsdk:"id"`
 	Sealed         types.String        `tfsdk:"sealed"`
 	Plaintext      types.String        `tfsdk:"plaintext"`
@@ -51,14 +104,21 @@ func (r *blindfoldResource) Metadata(_ context.Context, req resource.MetadataReq
 	resp.TypeName = req.ProviderTypeName + "_blindfold"
 }
 
-// Implement the Schema function for Resource interface. Blindfold resources are configured to accept plaintext data,
-// which will be stored as part of the resource's state unfortunately, and a name+namespace reference to a secret
-// policy document. A definitive path to vesctl can be provided as an option.
+// Implement the Schema function for Resource interface. Blindfold resources are configured to accept plaintext data, as
+// a sensitive or write-only value, and a name+namespace reference to a secret policy document. A salted hash of the
+// plaintext is stored in state so that changes can be detected, and so that plaintext can be moved to the write-only
+// attribute without blindfolding it again. A definitive path to vesctl can be provided as an option.
 func (r *blindfoldResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Generates a blindfolded secret from a base64 encoded source string.\n\n" +
-			"NOTE: The Terraform state *will include the unencrypted source value* that was provided " +
-			"through the `plaintext` attribute.",
+		Version: 1,
+		MarkdownDescription: "Generates a blindfolded secret from a base64, UTF-8, or hex encoded source string.\n\n" +
+			"NOTE: Terraform stores a value that is set in the `plaintext` attribute in state, so the state *will " +
+			"include the unencrypted source value* while `plaintext` is used. Use `plaintext_wo` or `source` to keep " +
+			"the source value out of state; changes are detected with the salted hash recorded in `plaintext_hash`. " +
+			"State written by earlier versions of the provider is upgraded to replace the unencrypted value with the " +
+			"hash. If `plaintext` is still set in configuration, the next plan shows an in-place update that stores " +
+			"it in state again, without blindfolding the secret again. An existing secret can be moved to " +
+			"`plaintext_wo` or `source` without blindfolding it again, as long as the value matches `plaintext_hash`.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The computed resource identifier for the blindfolded secret.",
@@ -72,13 +132,101 @@ func (r *blindfoldResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 			},
 			"plaintext": schema.StringAttribute{
-				Description: "The base64 encoded plaintext data that will be blindfolded.",
-				Required:    true,
-				Sensitive:   true,
+				MarkdownDescription: "The encoded plaintext data that will be blindfolded. Exactly one of " +
+					"`plaintext`, `plaintext_wo`, or `source` must be provided.",
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					validPlaintextEncoding(),
+				},
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIf(
+						requiresReplaceIfPlaintextChanged,
+						"Changing the plaintext will blindfold the secret again.",
+						"Changing the plaintext will blindfold the secret again.",
+					),
+				},
+			},
+			"plaintext_wo": schema.StringAttribute{
+				MarkdownDescription: "The encoded plaintext data that will be blindfolded, as a write-only value " +
+					"that is never stored in Terraform plan or state. Requires Terraform 1.11 or later, and must be " +
+					"accompanied by `plaintext_wo_version`.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				Validators: []validator.String{
+					validPlaintextEncoding(),
+				},
+			},
+			"plaintext_encoding": schema.StringAttribute{
+				MarkdownDescription: "The encoding of `plaintext` or `plaintext_wo`; one of `base64`, the default, " +
+					"`utf8`, or `hex`. The plaintext is validated against the encoding during plan, and changing the " +
+					"encoding will blindfold the secret again. Values read from `source` are blindfolded as-is.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(plaintextEncodingBase64),
+				Validators: []validator.String{
+					validPlaintextEncoding(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						requiresReplaceIfPlaintextEncodingChanged,
+						"Changing the encoding will blindfold the secret again.",
+						"Changing the encoding will blindfold the secret again.",
+					),
+				},
+			},
+			"plaintext_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "A version number for the value provided in `plaintext_wo`; changing the " +
+					"version will blindfold the current `plaintext_wo` value again.",
+				Optional: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplaceIf(
+						requiresReplaceIfPlaintextWOVersionChanged,
+						"Changing the version will blindfold the secret again.",
+						"Changing the version will blindfold the secret again.",
+					),
+				},
+			},
+			"source": plaintextSourceSchema("plaintext"),
+			"plaintext_hash": schema.StringAttribute{
+				MarkdownDescription: "A salted SHA-256 hash of the `plaintext` or `source` value that was blindfolded, " +
+					"used to detect changes to the plaintext without storing it in state.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"location": schema.StringAttribute{
+				MarkdownDescription: "The F5XC location of the sealed data, ready to use as the `location` of a " +
+					"`blindfold_secret_info` block.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"secret_info_json": schema.StringAttribute{
+				MarkdownDescription: "A JSON encoded F5XC `blindfold_secret_info` object that references the sealed " +
+					"data through its `location` field, with `decryption_provider` and `store_provider` fields set " +
+					"from the attributes of the same name.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"decryption_provider": schema.StringAttribute{
+				MarkdownDescription: "An optional name of the F5XC Secret Management Access object that will decrypt " +
+					"the secret, set as the `decryption_provider` field of `secret_info_json`. If unspecified the " +
+					"field is empty and F5XC uses its default. Changing the value does not blindfold the secret again.",
+				Optional: true,
+			},
+			"store_provider": schema.StringAttribute{
+				MarkdownDescription: "An optional name of the F5XC Secret Management Access object of the store that " +
+					"holds the sealed data, set as the `store_provider` field of `secret_info_json`. If unspecified " +
+					"the field is empty and F5XC reads the sealed data from `location`. Changing the value does not " +
+					"blindfold the secret again.",
+				Optional: true,
+			},
 			"policy_document": schema.SingleNestedAttribute{
 				Required: true,
 				Attributes: map[string]schema.Attribute{
@@ -103,6 +251,40 @@ func (r *blindfoldResource) Schema(_ context.Context, _ resource.SchemaRequest,
 					"unspecified, the first vesctl binary found in PATH will be used",
 				Optional: true,
 			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "An arbitrary map of values that, when changed, will cause the secret to be " +
+					"blindfolded again. Adding triggers also blindfolds the secret again, unless it was imported and has not " +
+					"been blindfolded by the provider since.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplaceIf(
+						requiresReplaceIfTriggersChanged,
+						"Changing the triggers will blindfold the secret again.",
+						"Changing the triggers will blindfold the secret again.",
+					),
+				},
+			},
+			"rotation_period": schema.StringAttribute{
+				MarkdownDescription: "An optional duration, such as `720h`, after which the secret will be " +
+					"blindfolded again. The age of the secret is checked against `created_at` during plan.",
+				Optional: true,
+			},
+			"created_at": schema.StringAttribute{
+				Description: "The RFC3339 timestamp of when the secret was blindfolded.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"replace_on_rotation": schema.BoolAttribute{
+				MarkdownDescription: "If true, the default, the secret will be blindfolded again when the tenant's " +
+					"public key is rotated or the secret policy document is changed. If false, a warning will be " +
+					"reported instead.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
 		},
 	}
 }
@@ -121,8 +303,56 @@ func (r *blindfoldResource) Configure(_ context.Context, req resource.ConfigureR
 		)
 		return
 	}
-	r.client = cfg.client
 	r.timeout = cfg.timeout
+	r.cache = cfg.cache
+}
+
+// Implement the ValidateConfig function for ResourceWithValidateConfig interface. Exactly one of plaintext,
+// plaintext_wo, or source must be set, plaintext_wo_version is only meaningful alongside plaintext_wo, and
+// rotation_period must be a valid duration.
+func (r *blindfoldResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) { //nolint:gocritic // Provider interface passes ValidateConfigRequest by value.
+	resp.Diagnostics.Append(validateRotationPeriod(ctx, &req.Config)...)
+	resp.Diagnostics.Append(validatePlaintextSource(ctx, &req.Config)...)
+	var model blindfoldResourceModel
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("plaintext"), &model.Plaintext)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("plaintext_wo"), &model.PlaintextWO)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("plaintext_wo_version"), &model.PlaintextWOVersion)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("source"), &model.Source)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	count := 0
+	for _, value := range []attr.Value{model.Plaintext, model.PlaintextWO, model.Source} {
+		if !value.IsNull() {
+			count++
+		}
+	}
+	switch {
+	case count > 1:
+		resp.Diagnostics.AddError(
+			"Conflicting plaintext attributes",
+			"Only one of plaintext, plaintext_wo, or source can be set for a blindfold resource.",
+		)
+	case count == 0:
+		resp.Diagnostics.AddError(
+			"Missing plaintext attribute",
+			"One of plaintext, plaintext_wo, or source must be set for a blindfold resource.",
+		)
+	case !model.PlaintextWO.IsNull() && model.PlaintextWOVersion.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("plaintext_wo_version"),
+			"Missing plaintext_wo_version attribute",
+			"The plaintext_wo_version attribute must be set when plaintext_wo is used; change the version to "+
+				"blindfold a new write-only value.",
+		)
+	case model.PlaintextWO.IsNull() && !model.PlaintextWOVersion.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("plaintext_wo_version"),
+			"Unexpected plaintext_wo_version attribute",
+			"The plaintext_wo_version attribute can only be set when plaintext_wo is used.",
+		)
+	}
 }
 
 // Implement the Create function for Resource interface. Blindfold resources are entirely ephemeral and any change in
@@ -148,19 +378,28 @@ func (r *blindfoldResource) Create(ctx context.Context, req resource.CreateReque
 	}
 	model.ID = types.StringValue(id.String())
 
-	tflog.Debug(ctx, "Decoding plaintext value from base64")
-	plaintext, err := base64.StdEncoding.DecodeString(model.Plaintext.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error decoding Base64 plaintext",
-			"Failed to decode base64 plaintext to byte array, unexpected error: "+err.Error(),
-		)
+	plaintext, hashed, diags := r.plaintext(ctx, &req.Config, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer clear(plaintext)
+	model.PlaintextHash = types.StringNull()
+	if !hashed.IsNull() {
+		hash, err := newPlaintextHash(hashed.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error hashing plaintext",
+				"Failed to compute a salted hash of plaintext, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		model.PlaintextHash = types.StringValue(hash)
 	}
+	model.PlaintextWO = types.StringNull()
 
 	tflog.Debug(ctx, "Fetching Public Key")
-	clientCtx, cancel := context.WithTimeout(ctx, r.timeout)
-	defer cancel()
-	pubKey, err := f5xc.GetPublicKey(clientCtx, r.client, nil)
+	pubKey, err := r.cache.publicKey(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error retrieving PublicKey",
@@ -168,19 +407,9 @@ func (r *blindfoldResource) Create(ctx context.Context, req resource.CreateReque
 		)
 		return
 	}
-	if pubKey == nil {
-		resp.Diagnostics.AddError(
-			"Error retrieving PublicKey",
-			"PublicKey was not found for this account",
-		)
-		return
-	}
-	cancel()
 
 	tflog.Debug(ctx, "Fetching Secret Policy Document")
-	clientCtx, cancel = context.WithTimeout(ctx, r.timeout)
-	defer cancel()
-	policyDoc, err := f5xc.GetSecretPolicyDocument(clientCtx, r.client, model.PolicyDocument.Name.ValueString(), model.PolicyDocument.Namespace.ValueString())
+	policyDoc, err := r.cache.secretPolicyDocument(ctx, model.PolicyDocument.Name.ValueString(), model.PolicyDocument.Namespace.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error retrieving SecretPolicyDocument",
@@ -188,17 +417,19 @@ func (r *blindfoldResource) Create(ctx context.Context, req resource.CreateReque
 		)
 		return
 	}
-	if policyDoc == nil {
+
+	rotation, err := newBlindfoldPrivateState(pubKey, policyDoc)
+	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error retrieving SecretPolicyDocument",
-			"SecretPolicyDocument was not found; check the assigned values for name and namespace",
+			"Error recording PublicKey version",
+			"Failed to record PublicKey version and SecretPolicyDocument fingerprint, unexpected error: "+err.Error(),
 		)
 		return
 	}
-	cancel()
+	resp.Diagnostics.Append(rotation.set(ctx, resp.Private)...)
 
 	tflog.Debug(ctx, "Executing blindfold")
-	clientCtx, cancel = context.WithTimeout(ctx, r.timeout)
+	clientCtx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 	sealed, err := blindfold.Seal(clientCtx, model.Vesctl.ValueString(), plaintext, pubKey, policyDoc)
 	if err != nil {
@@ -209,6 +440,17 @@ func (r *blindfoldResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 	model.Sealed = types.StringValue(string(sealed))
+	location, secretInfo, err := blindfoldSecretInfo(model.Sealed.ValueString(), model.DecryptionProvider.ValueString(), model.StoreProvider.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error encoding blindfold_secret_info",
+			"Failed to encode blindfold_secret_info, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	model.Location = types.StringValue(location)
+	model.SecretInfoJSON = types.StringValue(secretInfo)
+	model.CreatedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
 
 	diags = resp.State.Set(ctx, model)
 	resp.Diagnostics.Append(diags...)
@@ -217,9 +459,146 @@ func (r *blindfoldResource) Create(ctx context.Context, req resource.CreateReque
 	}
 }
 
-// Implement the Read function for Resource interface. Blindfold resources do not create any state to read in, so this
-// function does nothing. Terraform state will be unchanged.
-func (r *blindfoldResource) Read(_ context.Context, _ resource.ReadRequest, _ *resource.ReadResponse) { //nolint:gocritic // Provider interface passes ReadRequest by value.
+// Implement the Read function for Resource interface. The sealed value cannot be verified, but the public key version
+// and secret policy document that were used to blindfold the secret are compared to the current values in F5XC so that
+// the secret can be blindfolded again if either has changed.
+func (r *blindfoldResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) { //nolint:gocritic // Provider interface passes ReadRequest by value.
+	tflog.Info(ctx, "Reading blindfold resource")
+	var model blindfoldResourceModel
+	diags := req.State.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "policy_doc_name", model.PolicyDocument.Name.ValueString())
+	ctx = tflog.SetField(ctx, "policy_doc_namespace", model.PolicyDocument.Namespace.ValueString())
+
+	checkBlindfoldRotation(ctx, r.cache, []policyDocumentModel{model.PolicyDocument}, model.ReplaceOnRotation.ValueBool(), req.Private, resp)
+	checkBlindfoldCreatedAt(ctx, model.CreatedAt, resp)
+	checkBlindfoldSecretInfo(ctx, &req.State, resp)
+}
+
+// Implement the ModifyPlan function for ResourceWithModifyPlan interface. The resource will be replaced if Read has
+// detected that the public key or secret policy document has changed since the secret was blindfolded, or if the
+// plaintext, source, or a plaintext_wo value that replaces them no longer matches the salted hash recorded when it was
+// blindfolded.
+func (r *blindfoldResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) { //nolint:gocritic // Provider interface passes ModifyPlanRequest by value.
+	planBlindfoldRotation(ctx, &req, resp)
+	planBlindfoldSecretInfo(ctx, &req, resp)
+	planBlindfoldRotationPeriod(ctx, &req, resp)
+	// Nothing more to do when the resource is being created or destroyed; the hash will be computed by Create.
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	var plaintext, recorded types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("plaintext"), &plaintext)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("plaintext_hash"), &recorded)...)
+	var sourceObj types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("source"), &sourceObj)...)
+	source, diags := getPlaintextSource(ctx, &req.Config, path.Root("source"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if hasUnknownValue(sourceObj) {
+		// The source cannot be read until apply, so the secret must be blindfolded again from whatever it returns.
+		tflog.Info(ctx, "Plaintext source is unknown during plan, requiring replacement")
+		planBlindfoldReseal(ctx, resp)
+		return
+	}
+	if source != nil {
+		value, err := source.read(ctx, r.timeout)
+		if errors.Is(err, errPlaintextSourceUnknown) {
+			tflog.Info(ctx, "Plaintext source is unknown during plan, requiring replacement")
+			planBlindfoldReseal(ctx, resp)
+			return
+		}
+		if err != nil {
+			// The source may depend on values that are only available during apply, so leave any failure for Create
+			// to report.
+			tflog.Debug(ctx, "Unable to read plaintext source during plan", map[string]any{"error": err.Error()})
+			return
+		}
+		plaintext = types.StringValue(string(value))
+		clear(value)
+	}
+	if plaintext.IsNull() {
+		// Changes to plaintext_wo are signalled by plaintext_wo_version, and it is not hashed. A recorded hash means
+		// that the secret was blindfolded from plaintext or source, so a write-only value that replaces them must be
+		// compared with the hash.
+		var plaintextWO types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("plaintext_wo"), &plaintextWO)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("plaintext_hash"), types.StringNull())...)
+		if resp.Diagnostics.HasError() || recorded.IsNull() || plaintextWO.IsNull() {
+			return
+		}
+		plaintext = plaintextWO
+	}
+	switch {
+	case plaintext.IsUnknown():
+		tflog.Debug(ctx, "Plaintext is unknown during plan, unable to compare with recorded hash")
+		return
+	case recorded.IsNull():
+		// Imported secrets do not have a recorded hash, and the plaintext that was used to create the sealed value is
+		// unknown; leave the sealed value in place rather than forcing a new blindfold.
+		tflog.Debug(ctx, "No recorded plaintext hash, unable to compare with plaintext")
+		return
+	}
+	matches, err := plaintextHashMatches(recorded.ValueString(), plaintext.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("plaintext_hash"),
+			"Unable to compare plaintext with recorded hash",
+			"The recorded plaintext hash could not be parsed and the secret will be blindfolded again, unexpected "+
+				"error: "+err.Error(),
+		)
+	}
+	if matches {
+		return
+	}
+	tflog.Info(ctx, "Plaintext has changed, requiring replacement")
+	planBlindfoldReseal(ctx, resp)
+}
+
+// Marks the plaintext hash and sealed value as unknown in the plan, and requires the resource to be replaced so that
+// the secret is blindfolded again.
+func planBlindfoldReseal(ctx context.Context, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("plaintext_hash"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("sealed"), types.StringUnknown())...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("plaintext_hash"))
+}
+
+// Implement the ImportState function for ResourceWithImportState interface. Existing sealed values are imported using
+// an identifier of the form namespace/policy_name:sealed, where sealed is the base64 encoded sealed value or the path
+// to a file that contains it.
+func (r *blindfoldResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	policy, sealedRef, ok := parseBlindfoldImportID(req.ID)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Invalid import identifier",
+			"Expected an import identifier of the form namespace/policy_name:sealed, got: "+req.ID,
+		)
+		return
+	}
+	resp.Diagnostics.Append(importBlindfoldState(ctx, &policy, sealedRef, &resp.State)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("plaintext_encoding"), plaintextEncodingBase64)...)
+	resp.Diagnostics.AddWarning(
+		"Plaintext of imported secret is unknown",
+		"The plaintext that was used to create the imported sealed value cannot be verified, so changes to plaintext "+
+			"will not be detected until the secret is blindfolded again.",
+	)
+}
+
+// Implement the UpgradeState function for ResourceWithUpgradeState interface. Version 0 of the schema stored the
+// plaintext in state; the upgraded state replaces it with a salted hash, so that the sealed value does not have to be
+// blindfolded again while the plaintext is unchanged.
+func (r *blindfoldResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   blindfoldResourceSchemaV0(),
+			StateUpgrader: upgradeBlindfoldResourceStateV0,
+		},
+	}
 }
 
 // Implement the Update function for Resource interface. Blindfold resources do not create any state to update, so this
@@ -244,3 +623,333 @@ func (r *blindfoldResource) Update(ctx context.Context, req resource.UpdateReque
 // function does nothing. Terraform state will be deleted as long as the function does not add diagnostics to the response.
 func (r *blindfoldResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) { //nolint:gocritic // Provider interface passes DeleteRequest by value.
 }
+
+// Returns version 0 of the blindfold resource schema, which is needed to upgrade state that was created before the
+// plaintext hash was recorded.
+func blindfoldResourceSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		MarkdownDescription: "Generates a blindfolded secret from a base64 encoded source string.\n\n" +
+			"NOTE: The Terraform state *will include the unencrypted source value* that was provided " +
+			"through the `plaintext` attribute.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The computed resource identifier for the blindfolded secret.",
+				Computed:    true,
+			},
+			"sealed": schema.StringAttribute{
+				Description: "The base64 encoded, sealed data resulting from a blindfold.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"plaintext": schema.StringAttribute{
+				Description: "The base64 encoded plaintext data that will be blindfolded.",
+				Required:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"policy_document": schema.SingleNestedAttribute{
+				Required: true,
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Description: "The name of the F5XC PolicyDocument to use for blindfold.",
+						Required:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"namespace": schema.StringAttribute{
+						Description: "The namespace of the F5XC PolicyDocument to use for blindfold.",
+						Required:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+				},
+			},
+			"vesctl": schema.StringAttribute{
+				MarkdownDescription: "The path to `vesctl` binary to use for blindfolding. If " +
+					"unspecified, the first vesctl binary found in PATH will be used",
+				Optional: true,
+			},
+		},
+	}
+}
+
+// Returns the plaintext to blindfold from the plaintext, source, or plaintext_wo attribute of the config, and the value
+// that should be hashed to detect changes. Write-only values are never included in the plan and must be retrieved from
+// config; plaintext_wo values are not hashed as changes are signalled by plaintext_wo_version.
+func (r *blindfoldResource) plaintext(ctx context.Context, config *tfsdk.Config, model *blindfoldResourceModel) ([]byte, types.String, diag.Diagnostics) {
+	var encoded types.String
+	diags := config.GetAttribute(ctx, path.Root("plaintext"), &encoded)
+	if diags.HasError() {
+		return nil, types.StringNull(), diags
+	}
+	hashed := encoded
+	if encoded.IsNull() {
+		source, sourceDiags := getPlaintextSource(ctx, config, path.Root("source"))
+		diags.Append(sourceDiags...)
+		if diags.HasError() {
+			return nil, types.StringNull(), diags
+		}
+		if source != nil {
+			tflog.Debug(ctx, "Reading plaintext value from source")
+			value, err := source.read(ctx, r.timeout)
+			if err != nil {
+				diags.AddAttributeError(
+					path.Root("source"),
+					"Error reading plaintext source",
+					"Failed to read plaintext from source, unexpected error: "+err.Error(),
+				)
+				return nil, types.StringNull(), diags
+			}
+			return value, types.StringValue(string(value)), diags
+		}
+		diags.Append(config.GetAttribute(ctx, path.Root("plaintext_wo"), &encoded)...)
+		if diags.HasError() {
+			return nil, types.StringNull(), diags
+		}
+		hashed = types.StringNull()
+	}
+
+	tflog.Debug(ctx, "Decoding plaintext value", map[string]any{"plaintext_encoding": model.PlaintextEncoding.ValueString()})
+	plaintext, err := decodePlaintext(model.PlaintextEncoding.ValueString(), encoded.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error decoding plaintext",
+			"Failed to decode plaintext to byte array, unexpected error: "+err.Error(),
+		)
+		return nil, types.StringNull(), diags
+	}
+	return plaintext, hashed, diags
+}
+
+// Upgrades version 0 of the blindfold resource state by replacing the plaintext value with a salted hash of it, so that
+// existing state no longer contains the plaintext. ModifyPlan compares the plaintext of the config with the hash, so the
+// secret is not blindfolded again unless the value has changed. The hash is recorded in the plaintext_hash attribute
+// rather than private state, as a state upgrader cannot set private state. Attributes that were added since version 0
+// are set to their defaults.
+func upgradeBlindfoldResourceStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) { //nolint:gocritic // StateUpgrader interface passes UpgradeStateRequest by value.
+	tflog.Info(ctx, "Upgrading blindfold resource state from version 0")
+	var prior blindfoldResourceModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	model := blindfoldResourceModel{
+		ID:                 prior.ID,
+		Sealed:             prior.Sealed,
+		Location:           types.StringNull(),
+		SecretInfoJSON:     types.StringNull(),
+		DecryptionProvider: types.StringNull(),
+		StoreProvider:      types.StringNull(),
+		Plaintext:          types.StringNull(),
+		PlaintextWO:        types.StringNull(),
+		PlaintextWOVersion: types.Int64Null(),
+		PlaintextHash:      types.StringNull(),
+		PlaintextEncoding:  types.StringValue(plaintextEncodingBase64),
+		Source:             types.ObjectNull(plaintextSourceAttrTypes()),
+		PolicyDocument:     prior.PolicyDocument,
+		Vesctl:             prior.Vesctl,
+		Triggers:           types.MapNull(types.StringType),
+		RotationPeriod:     types.StringNull(),
+		CreatedAt:          types.StringNull(),
+		ReplaceOnRotation:  types.BoolValue(true),
+	}
+	if !prior.Plaintext.IsNull() {
+		hash, err := newPlaintextHash(prior.Plaintext.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error hashing plaintext",
+				"Failed to compute a salted hash of plaintext, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		model.PlaintextHash = types.StringValue(hash)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+}
+
+// Requires replacement when the plaintext is changed to a different value. Moving the value between plaintext and
+// source or plaintext_wo is left for ModifyPlan to compare with the recorded plaintext hash, which also allows the
+// plaintext of imported secrets to be adopted without blindfolding them again.
+func requiresReplaceIfPlaintextChanged(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) { //nolint:gocritic // RequiresReplaceIfFunc passes StringRequest by value.
+	resp.RequiresReplace = !req.StateValue.IsNull() && !req.PlanValue.IsNull()
+}
+
+// Requires replacement when plaintext_wo_version is changed or removed. A version that is added to a secret that was
+// blindfolded from plaintext or source is left for ModifyPlan to compare with the recorded plaintext hash, and a version
+// that is added to an imported secret is adopted without blindfolding it again.
+func requiresReplaceIfPlaintextWOVersionChanged(_ context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) { //nolint:gocritic // RequiresReplaceIfFunc passes Int64Request by value.
+	resp.RequiresReplace = !req.StateValue.IsNull()
+}
+
+// Returns a salted hash of plaintext, formatted as the hex encoded salt and HMAC-SHA256 digest separated by a colon.
+func newPlaintextHash(plaintext string) (string, error) {
+	return newSaltedHash(strings.NewReader(plaintext))
+}
+
+// Returns true if plaintext matches the salted hash.
+func plaintextHashMatches(hash, plaintext string) (bool, error) {
+	return saltedHashMatches(hash, strings.NewReader(plaintext))
+}
+
+// Returns a salted hash of the content read from r, formatted as the hex encoded salt and HMAC-SHA256 digest separated
+// by a colon.
+func newSaltedHash(r io.Reader) (string, error) {
+	salt := make([]byte, plaintextHashSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+	digest, err := saltedDigest(salt, r)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(salt) + ":" + hex.EncodeToString(digest), nil
+}
+
+// Returns true if the content read from r matches the salted hash.
+func saltedHashMatches(hash string, r io.Reader) (bool, error) {
+	encodedSalt, encodedDigest, ok := strings.Cut(hash, ":")
+	if !ok {
+		return false, errInvalidPlaintextHash
+	}
+	salt, err := hex.DecodeString(encodedSalt)
+	if err != nil {
+		return false, fmt.Errorf("%w: failed to decode salt: %w", errInvalidPlaintextHash, err)
+	}
+	digest, err := hex.DecodeString(encodedDigest)
+	if err != nil {
+		return false, fmt.Errorf("%w: failed to decode digest: %w", errInvalidPlaintextHash, err)
+	}
+	actual, err := saltedDigest(salt, r)
+	if err != nil {
+		return false, err
+	}
+	return hmac.Equal(digest, actual), nil
+}
+
+// Returns the HMAC-SHA256 digest of the content read from r, keyed by salt.
+func saltedDigest(salt []byte, r io.Reader) ([]byte, error) {
+	mac := hmac.New(sha256.New, salt)
+	if _, err := io.Copy(mac, r); err != nil {
+		return nil, fmt.Errorf("failed to read content: %w", err)
+	}
+	return mac.Sum(nil), nil
+}
+
+// Records the location and blindfold_secret_info of secrets that were blindfolded or imported before those attributes
+// were recorded, or before blindfold_secret_info included the decryption and store providers.
+func checkBlindfoldSecretInfo(ctx context.Context, state *tfsdk.State, resp *resource.ReadResponse) {
+	var sealed, recorded, decryptionProvider, storeProvider types.String
+	resp.Diagnostics.Append(state.GetAttribute(ctx, path.Root("sealed"), &sealed)...)
+	resp.Diagnostics.Append(state.GetAttribute(ctx, path.Root("secret_info_json"), &recorded)...)
+	resp.Diagnostics.Append(state.GetAttribute(ctx, path.Root("decryption_provider"), &decryptionProvider)...)
+	resp.Diagnostics.Append(state.GetAttribute(ctx, path.Root("store_provider"), &storeProvider)...)
+	if resp.Diagnostics.HasError() || sealed.IsNull() {
+		return
+	}
+	value, secretInfo, err := blindfoldSecretInfo(sealed.ValueString(), decryptionProvider.ValueString(), storeProvider.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error encoding blindfold_secret_info",
+			"Failed to encode blindfold_secret_info, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if secretInfo == recorded.ValueString() {
+		return
+	}
+	tflog.Debug(ctx, "Recording location and blindfold_secret_info for blindfold resource")
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("location"), value)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("secret_info_json"), secretInfo)...)
+}
+
+// Plans the blindfold_secret_info of a secret that is not being blindfolded again, so that changes to the decryption or
+// store providers are applied in place.
+func planBlindfoldSecretInfo(ctx context.Context, req *resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when the resource is being created or destroyed.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	var sealed, decryptionProvider, storeProvider types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("sealed"), &sealed)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("decryption_provider"), &decryptionProvider)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("store_provider"), &storeProvider)...)
+	if resp.Diagnostics.HasError() || sealed.IsNull() || sealed.IsUnknown() {
+		return
+	}
+	if decryptionProvider.IsUnknown() || storeProvider.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("secret_info_json"), types.StringUnknown())...)
+		return
+	}
+	_, secretInfo, err := blindfoldSecretInfo(sealed.ValueString(), decryptionProvider.ValueString(), storeProvider.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error encoding blindfold_secret_info",
+			"Failed to encode blindfold_secret_info, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("secret_info_json"), secretInfo)...)
+}
+
+// Parses a blindfold import identifier of the form namespace/policy_name:sealed, returning the referenced policy
+// document and the sealed reference.
+func parseBlindfoldImportID(id string) (policyDocumentModel, string, bool) {
+	policyRef, sealedRef, ok := strings.Cut(id, ":")
+	if !ok || sealedRef == "" {
+		return policyDocumentModel{}, "", false
+	}
+	namespace, name, ok := strings.Cut(policyRef, "/")
+	if !ok || namespace == "" || name == "" || strings.Contains(name, "/") {
+		return policyDocumentModel{}, "", false
+	}
+	return policyDocumentModel{
+		Name:      types.StringValue(name),
+		Namespace: types.StringValue(namespace),
+	}, sealedRef, true
+}
+
+// Sets the attributes common to imported blindfold resources in state. The sealed reference is read from a file if it
+// is the path to an existing file, otherwise it must be a base64 encoded sealed value.
+func importBlindfoldState(ctx context.Context, policy *policyDocumentModel, sealedRef string, state *tfsdk.State) diag.Diagnostics {
+	var diags diag.Diagnostics
+	sealed := sealedRef
+	if stat, err := os.Stat(sealedRef); err == nil && !stat.IsDir() {
+		tflog.Debug(ctx, "Reading sealed value from file")
+		data, err := os.ReadFile(sealedRef)
+		if err != nil {
+			diags.AddError(
+				"Error reading sealed file",
+				"Failed to read sealed value from "+sealedRef+", unexpected error: "+err.Error(),
+			)
+			return diags
+		}
+		sealed = strings.TrimSpace(string(data))
+	}
+	if _, err := base64.StdEncoding.DecodeString(sealed); err != nil {
+		diags.AddError(
+			"Invalid sealed value",
+			"The imported sealed value must be base64 encoded, or the path to a file containing a base64 encoded "+
+				"sealed value, unexpected error: "+err.Error(),
+		)
+		return diags
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		diags.AddError(
+			"Error computing id",
+			"Failed to compute a new id for the resource, unexpected error: "+err.Error(),
+		)
+		return diags
+	}
+	diags.Append(state.SetAttribute(ctx, path.Root("id"), id.String())...)
+	diags.Append(state.SetAttribute(ctx, path.Root("sealed"), sealed)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("policy_document"), policy)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("replace_on_rotation"), true)...)
+	return diags
+}
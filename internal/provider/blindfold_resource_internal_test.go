@@ -0,0 +1,278 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestParseBlindfoldImportID(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		id                string
+		expectedNamespace string
+		expectedName      string
+		expectedSealed    string
+		expectedOK        bool
+	}{
+		{id: "shared/policy:c2VhbGVk", expectedNamespace: "shared", expectedName: "policy", expectedSealed: "c2VhbGVk", expectedOK: true},
+		{id: "shared/policy:/path/to/sealed.txt", expectedNamespace: "shared", expectedName: "policy", expectedSealed: "/path/to/sealed.txt", expectedOK: true},
+		{id: "shared/policy:C:/sealed.txt", expectedNamespace: "shared", expectedName: "policy", expectedSealed: "C:/sealed.txt", expectedOK: true},
+		{id: "shared/policy"},
+		{id: "shared/policy:"},
+		{id: "shared:c2VhbGVk"},
+		{id: "/policy:c2VhbGVk"},
+		{id: "shared/:c2VhbGVk"},
+		{id: "shared/policy/extra:c2VhbGVk"},
+	}
+	for _, test := range tests {
+		t.Run(test.id, func(t *testing.T) {
+			t.Parallel()
+			policy, sealed, ok := parseBlindfoldImportID(test.id)
+			if ok != test.expectedOK {
+				t.Fatalf("expected ok to be %t, got %t", test.expectedOK, ok)
+			}
+			if !ok {
+				return
+			}
+			if policy.Namespace.ValueString() != test.expectedNamespace || policy.Name.ValueString() != test.expectedName {
+				t.Errorf("expected policy %s/%s, got %s", test.expectedNamespace, test.expectedName, policy.key())
+			}
+			if sealed != test.expectedSealed {
+				t.Errorf("expected sealed %q, got %q", test.expectedSealed, sealed)
+			}
+		})
+	}
+}
+
+func TestBlindfoldResourceImportState(t *testing.T) {
+	t.Parallel()
+	sealedFile := filepath.Join(t.TempDir(), "sealed.txt")
+	if err := os.WriteFile(sealedFile, []byte("c2VhbGVkIGZyb20gZmlsZQ==\n"), 0o600); err != nil {
+		t.Fatalf("failed to write sealed file: %v", err)
+	}
+	tests := []struct {
+		name           string
+		id             string
+		expectedSealed string
+		expectedError  bool
+	}{
+		{name: "literal", id: "shared/policy:c2VhbGVk", expectedSealed: "c2VhbGVk"},
+		{name: "file", id: "shared/policy:" + sealedFile, expectedSealed: "c2VhbGVkIGZyb20gZmlsZQ=="},
+		{name: "invalid-id", id: "shared/policy", expectedError: true},
+		{name: "invalid-sealed", id: "shared/policy:not base64!", expectedError: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			state := testImportBlindfoldState(t, NewBlindfoldResource(), test.id, test.expectedError)
+			if test.expectedError {
+				return
+			}
+			var sealed types.String
+			var policy policyDocumentModel
+			state.GetAttribute(context.Background(), path.Root("sealed"), &sealed)
+			state.GetAttribute(context.Background(), path.Root("policy_document"), &policy)
+			if sealed.ValueString() != test.expectedSealed {
+				t.Errorf("expected sealed %q, got %q", test.expectedSealed, sealed.ValueString())
+			}
+			if policy.key() != "shared/policy" {
+				t.Errorf("expected policy shared/policy, got %s", policy.key())
+			}
+		})
+	}
+}
+
+// Imports a resource with the identifier, failing the test if the presence of error diagnostics does not match
+// expectedError, and returns the imported state.
+func testImportBlindfoldState(t *testing.T, r resource.Resource, id string, expectedError bool) *tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	importer, ok := r.(resource.ResourceWithImportState)
+	if !ok {
+		t.Fatalf("expected %T to implement ResourceWithImportState", r)
+	}
+	importer.ImportState(ctx, resource.ImportStateRequest{ID: id}, resp)
+	if resp.Diagnostics.HasError() != expectedError {
+		t.Fatalf("expected error to be %t, got diagnostics: %v", expectedError, resp.Diagnostics)
+	}
+	return &resp.State
+}
+
+// Upgrades version 0 state with the plaintext, failing the test on error, and returns the upgraded state.
+func testUpgradeBlindfoldStateV0(t *testing.T, plaintext string) *tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+	priorSchema := blindfoldResourceSchemaV0()
+	prior := tfsdk.State{Schema: priorSchema, Raw: tftypes.NewValue(priorSchema.Type().TerraformType(ctx), nil)}
+	if diags := prior.Set(ctx, &blindfoldResourceModelV0{
+		ID:        types.StringValue("test"),
+		Sealed:    types.StringValue("sealed"),
+		Plaintext: types.StringValue(plaintext),
+		PolicyDocument: policyDocumentModel{
+			Name:      types.StringValue("ves-io-allow-volterra"),
+			Namespace: types.StringValue("shared"),
+		},
+		Vesctl: types.StringNull(),
+	}); diags.HasError() {
+		t.Fatalf("failed to set prior state: %v", diags)
+	}
+	schemaResp := &resource.SchemaResponse{}
+	NewBlindfoldResource().Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	resp := &resource.UpgradeStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+	}
+	upgradeBlindfoldResourceStateV0(ctx, resource.UpgradeStateRequest{State: &prior}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected upgrade error: %v", resp.Diagnostics)
+	}
+	return &resp.State
+}
+
+func TestUpgradeBlindfoldResourceStateV0(t *testing.T) {
+	t.Parallel()
+	state := testUpgradeBlindfoldStateV0(t, "VGhpcyBpcyBhIHRlc3Q=")
+	var model blindfoldResourceModel
+	if diags := state.Get(context.Background(), &model); diags.HasError() {
+		t.Fatalf("failed to get upgraded state: %v", diags)
+	}
+	if !model.Plaintext.IsNull() {
+		t.Error("expected plaintext to be removed from upgraded state")
+	}
+	if matches, err := plaintextHashMatches(model.PlaintextHash.ValueString(), "VGhpcyBpcyBhIHRlc3Q="); err != nil || !matches {
+		t.Errorf("expected plaintext_hash to match plaintext, got %t: %v", matches, err)
+	}
+	if !model.ReplaceOnRotation.ValueBool() {
+		t.Error("expected replace_on_rotation to default to true")
+	}
+	if model.Sealed.ValueString() != "sealed" {
+		t.Errorf("expected sealed value to be kept, got %q", model.Sealed.ValueString())
+	}
+}
+
+// A config that still sets plaintext must be compared with the hash of upgraded state, and only blindfolded again when
+// the value has changed.
+func TestBlindfoldResourceModifyPlanUpgradedState(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name             string
+		plaintext        string
+		expectedReplaced bool
+	}{
+		{name: "unchanged", plaintext: "VGhpcyBpcyBhIHRlc3Q="},
+		{name: "changed", plaintext: "VGhpcyBpcyBhbm90aGVyIHRlc3Q=", expectedReplaced: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			state := testUpgradeBlindfoldStateV0(t, "VGhpcyBpcyBhIHRlc3Q=")
+			var model blindfoldResourceModel
+			if diags := state.Get(ctx, &model); diags.HasError() {
+				t.Fatalf("failed to get upgraded state: %v", diags)
+			}
+			model.Plaintext = types.StringValue(test.plaintext)
+			planned := tfsdk.State{Schema: state.Schema, Raw: state.Raw.Copy()}
+			if diags := planned.Set(ctx, &model); diags.HasError() {
+				t.Fatalf("failed to set plan: %v", diags)
+			}
+			model.ID = types.StringNull()
+			model.Sealed = types.StringNull()
+			model.PlaintextHash = types.StringNull()
+			model.ReplaceOnRotation = types.BoolNull()
+			model.PlaintextEncoding = types.StringNull()
+			config := tfsdk.State{Schema: state.Schema, Raw: state.Raw.Copy()}
+			if diags := config.Set(ctx, &model); diags.HasError() {
+				t.Fatalf("failed to set config: %v", diags)
+			}
+			resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw.Copy()}}
+			(&blindfoldResource{}).ModifyPlan(ctx, resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw},
+				State:  *state,
+				Plan:   tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw},
+			}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if replaced := len(resp.RequiresReplace) > 0; replaced != test.expectedReplaced {
+				t.Errorf("expected replacement to be %t, got %v", test.expectedReplaced, resp.RequiresReplace)
+			}
+		})
+	}
+}
+
+func TestRequiresReplaceIfTriggersChanged(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	imported := testImportBlindfoldState(t, NewBlindfoldResource(), "shared/policy:c2VhbGVk", false)
+	created := testImportBlindfoldState(t, NewBlindfoldResource(), "shared/policy:c2VhbGVk", false)
+	if diags := created.SetAttribute(ctx, path.Root("created_at"), "2024-01-01T00:00:00Z"); diags.HasError() {
+		t.Fatalf("failed to set created_at: %v", diags)
+	}
+	triggers := types.MapValueMust(types.StringType, map[string]attr.Value{"version": types.StringValue("1")})
+	tests := []struct {
+		name     string
+		state    *tfsdk.State
+		value    types.Map
+		expected bool
+	}{
+		{name: "imported", state: imported, value: types.MapNull(types.StringType)},
+		{name: "imported with triggers", state: imported, value: triggers, expected: true},
+		{name: "created", state: created, value: types.MapNull(types.StringType), expected: true},
+		{name: "created with triggers", state: created, value: triggers, expected: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			req := planmodifier.MapRequest{State: *test.state, StateValue: test.value}
+			resp := &mapplanmodifier.RequiresReplaceIfFuncResponse{}
+			requiresReplaceIfTriggersChanged(ctx, req, resp)
+			switch {
+			case resp.Diagnostics.HasError():
+				t.Errorf("unexpected error: %v", resp.Diagnostics)
+			case resp.RequiresReplace != test.expected:
+				t.Errorf("expected RequiresReplace to be %t, got %t", test.expected, resp.RequiresReplace)
+			}
+		})
+	}
+}
+
+// Changing the decryption or store providers must update blindfold_secret_info in place.
+func TestPlanBlindfoldSecretInfo(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	state := testImportBlindfoldState(t, NewBlindfoldResource(), "shared/policy:c2VhbGVk", false)
+	planned := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw.Copy()}
+	if diags := planned.SetAttribute(ctx, path.Root("decryption_provider"), "decryptor"); diags.HasError() {
+		t.Fatalf("failed to set decryption_provider: %v", diags)
+	}
+	resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw.Copy()}}
+	planBlindfoldSecretInfo(ctx, &resource.ModifyPlanRequest{State: *state, Plan: planned}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	var secretInfo types.String
+	resp.Plan.GetAttribute(ctx, path.Root("secret_info_json"), &secretInfo)
+	expected := `{"decryption_provider":"decryptor","location":"string:///c2VhbGVk","store_provider":""}`
+	if secretInfo.ValueString() != expected {
+		t.Errorf("expected secret_info_json %s, got %s", expected, secretInfo)
+	}
+}
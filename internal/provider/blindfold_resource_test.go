@@ -1,9 +1,16 @@
 package provider_test
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func TestAccBlindfoldResource(t *testing.T) {
@@ -24,9 +31,395 @@ resource "f5xc_blindfold" "test" {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttrSet("f5xc_blindfold.test", "id"),
 					resource.TestCheckResourceAttr("f5xc_blindfold.test", "plaintext", "VGhpcyBpcyBhIHRlc3Q="),
+					resource.TestCheckResourceAttrSet("f5xc_blindfold.test", "plaintext_hash"),
+					resource.TestCheckResourceAttr("f5xc_blindfold.test", "replace_on_rotation", "true"),
+					resource.TestCheckResourceAttr("f5xc_blindfold.test", "plaintext_encoding", "base64"),
+					resource.TestMatchResourceAttr("f5xc_blindfold.test", "location", regexp.MustCompile(`^string:///.+`)),
+					resource.TestMatchResourceAttr("f5xc_blindfold.test", "secret_info_json", regexp.MustCompile(`"location":"string:///.+"`)),
 					resource.TestCheckResourceAttrSet("f5xc_blindfold.test", "sealed"),
 				),
 			},
+			// Importing the sealed value must adopt the configured plaintext without blindfolding it again.
+			{
+				ResourceName:      "f5xc_blindfold.test",
+				ImportState:       true,
+				ImportStateKind:   resource.ImportBlockWithID,
+				ImportStateIdFunc: testAccBlindfoldImportStateIDFunc("f5xc_blindfold.test"),
+				ImportPlanChecks: resource.ImportPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("f5xc_blindfold.test", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+			{
+				Config: providerConfig + `
+resource "f5xc_blindfold" "test" {
+	plaintext = "VGhpcyBpcyBhbm90aGVyIHRlc3Q="
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("f5xc_blindfold.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("f5xc_blindfold.test", "plaintext", "VGhpcyBpcyBhbm90aGVyIHRlc3Q="),
+					resource.TestCheckResourceAttrSet("f5xc_blindfold.test", "plaintext_hash"),
+					resource.TestCheckResourceAttrSet("f5xc_blindfold.test", "sealed"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBlindfoldResourceWriteOnly(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "f5xc_blindfold" "test" {
+	plaintext_wo = "VGhpcyBpcyBhIHRlc3Q="
+	plaintext_wo_version = 1
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("f5xc_blindfold.test", "id"),
+					resource.TestCheckNoResourceAttr("f5xc_blindfold.test", "plaintext"),
+					resource.TestCheckNoResourceAttr("f5xc_blindfold.test", "plaintext_wo"),
+					resource.TestCheckResourceAttr("f5xc_blindfold.test", "plaintext_wo_version", "1"),
+					resource.TestCheckResourceAttrSet("f5xc_blindfold.test", "sealed"),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "f5xc_blindfold" "test" {
+	plaintext_wo = "VGhpcyBpcyBhbm90aGVyIHRlc3Q="
+	plaintext_wo_version = 2
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("f5xc_blindfold.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("f5xc_blindfold.test", "plaintext_wo_version", "2"),
+					resource.TestCheckResourceAttrSet("f5xc_blindfold.test", "sealed"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBlindfoldResourceMoveToWriteOnly(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "f5xc_blindfold" "test" {
+	plaintext = "VGhpcyBpcyBhIHRlc3Q="
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("f5xc_blindfold.test", "plaintext", "VGhpcyBpcyBhIHRlc3Q="),
+					resource.TestCheckResourceAttrSet("f5xc_blindfold.test", "plaintext_hash"),
+				),
+			},
+			// Moving the same value to plaintext_wo must remove it from state without blindfolding it again.
+			{
+				Config: providerConfig + `
+resource "f5xc_blindfold" "test" {
+	plaintext_wo = "VGhpcyBpcyBhIHRlc3Q="
+	plaintext_wo_version = 1
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("f5xc_blindfold.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("f5xc_blindfold.test", "plaintext"),
+					resource.TestCheckNoResourceAttr("f5xc_blindfold.test", "plaintext_hash"),
+					resource.TestCheckResourceAttr("f5xc_blindfold.test", "plaintext_wo_version", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBlindfoldResourceSource(t *testing.T) {
+	t.Parallel()
+	plaintextFile := filepath.Join(t.TempDir(), "plaintext")
+	if err := os.WriteFile(plaintextFile, []byte("This is a test\n"), 0o600); err != nil {
+		t.Fatalf("failed to write plaintext file: %v", err)
+	}
+	fileConfig := providerConfig + `
+resource "f5xc_blindfold" "test" {
+	source = {
+		file = "` + plaintextFile + `"
+		trim_newline = true
+	}
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+}
+`
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fileConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("f5xc_blindfold.test", "source.file", plaintextFile),
+					resource.TestCheckResourceAttrSet("f5xc_blindfold.test", "plaintext_hash"),
+					resource.TestCheckResourceAttrSet("f5xc_blindfold.test", "sealed"),
+				),
+			},
+			// Changing the content of the source must blindfold it again.
+			{
+				PreConfig: func() {
+					if err := os.WriteFile(plaintextFile, []byte("This is another test\n"), 0o600); err != nil {
+						t.Fatalf("failed to update plaintext file: %v", err)
+					}
+				},
+				Config: fileConfig,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("f5xc_blindfold.test", plancheck.ResourceActionReplace),
+					},
+				},
+			},
+			// Reading the same value from a command must not blindfold it again.
+			{
+				Config: providerConfig + `
+resource "f5xc_blindfold" "test" {
+	source = {
+		command = {
+			program = "cat"
+			args = ["` + plaintextFile + `"]
+			timeout = "10s"
+		}
+		trim_newline = true
+	}
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("f5xc_blindfold.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("f5xc_blindfold.test", "source.command.program", "cat"),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "f5xc_blindfold" "test" {
+	source = {
+		env = "F5XC_BLINDFOLD_TEST"
+		file = "` + plaintextFile + `"
+	}
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid source`),
+			},
+		},
+	})
+}
+
+// Returns an ImportStateIdFunc that builds a blindfold import identifier from the policy document and sealed value of
+// the named resource.
+func testAccBlindfoldImportStateIDFunc(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resourceName) //nolint:err113 // Test helper error.
+		}
+		attrs := rs.Primary.Attributes
+		return attrs["policy_document.namespace"] + "/" + attrs["policy_document.name"] + ":" + attrs["sealed"], nil
+	}
+}
+
+func TestAccBlindfoldResourcePlaintextEncoding(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Plaintext that is not valid for the encoding must be rejected during plan.
+			{
+				Config: providerConfig + `
+resource "f5xc_blindfold" "test" {
+	plaintext = "This is a test"
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid plaintext`),
+			},
+			{
+				Config: providerConfig + `
+resource "f5xc_blindfold" "test" {
+	plaintext = "This is a test"
+	plaintext_encoding = "utf8"
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("f5xc_blindfold.test", "plaintext_encoding", "utf8"),
+					resource.TestCheckResourceAttrSet("f5xc_blindfold.test", "sealed"),
+				),
+			},
+			// Changing the encoding must blindfold the secret again.
+			{
+				Config: providerConfig + `
+resource "f5xc_blindfold" "test" {
+	plaintext = "5468697320697320612074657374"
+	plaintext_encoding = "hex"
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("f5xc_blindfold.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("f5xc_blindfold.test", "plaintext_encoding", "hex"),
+					resource.TestCheckResourceAttrSet("f5xc_blindfold.test", "sealed"),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "f5xc_blindfold" "test" {
+	plaintext = "5468697320697320612074657374"
+	plaintext_encoding = "base32"
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+}
+`,
+				ExpectError: regexp.MustCompile(`Unsupported plaintext encoding`),
+			},
+		},
+	})
+}
+
+func TestAccBlindfoldResourceRotation(t *testing.T) {
+	t.Parallel()
+	config := func(trigger, rotationPeriod string) string {
+		return providerConfig + `
+resource "f5xc_blindfold" "test" {
+	plaintext = "VGhpcyBpcyBhIHRlc3Q="
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+	triggers = {
+		version = "` + trigger + `"
+	}
+	rotation_period = "` + rotationPeriod + `"
+}
+`
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config("1", "not-a-duration"),
+				ExpectError: regexp.MustCompile(`Invalid rotation_period`),
+			},
+			{
+				Config: config("1", "8760h"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("f5xc_blindfold.test", "triggers.version", "1"),
+					resource.TestCheckResourceAttr("f5xc_blindfold.test", "rotation_period", "8760h"),
+					resource.TestCheckResourceAttrSet("f5xc_blindfold.test", "created_at"),
+					resource.TestCheckResourceAttrSet("f5xc_blindfold.test", "sealed"),
+				),
+			},
+			// Importing the sealed value must adopt the configured triggers without blindfolding the plaintext again.
+			{
+				ResourceName:      "f5xc_blindfold.test",
+				ImportState:       true,
+				ImportStateKind:   resource.ImportBlockWithID,
+				ImportStateIdFunc: testAccBlindfoldImportStateIDFunc("f5xc_blindfold.test"),
+				ImportPlanChecks: resource.ImportPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("f5xc_blindfold.test", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+			// Changing a trigger value must blindfold the plaintext again.
+			{
+				Config: config("2", "8760h"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("f5xc_blindfold.test", plancheck.ResourceActionReplace),
+					},
+				},
+			},
+			// A secret that is older than rotation_period must be blindfolded again on every plan.
+			{
+				Config: config("2", "1ns"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("f5xc_blindfold.test", plancheck.ResourceActionReplace),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("f5xc_blindfold.test", plancheck.ResourceActionReplace),
+					},
+				},
+				ExpectNonEmptyPlan: true,
+			},
 		},
 	})
 }
@@ -0,0 +1,525 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/memes/f5xc/blindfold"
+)
+
+var (
+	_ resource.Resource                   = &blindfoldSOPSResource{}
+	_ resource.ResourceWithModifyPlan     = &blindfoldSOPSResource{}
+	_ resource.ResourceWithConfigure      = &blindfoldSOPSResource{}
+	_ resource.ResourceWithValidateConfig = &blindfoldSOPSResource{}
+)
+
+const (
+	// The default sops binary, found in PATH.
+	defaultSOPSBinary = "sops"
+	// The environment variable used by sops to locate age identities.
+	sopsAgeKeyFileEnv = "SOPS_AGE_KEY_FILE"
+)
+
+var (
+	// errInvalidSOPSExtract is returned when an extract key path cannot be converted to a sops extract expression.
+	errInvalidSOPSExtract = errors.New("invalid extract key path")
+	// errSOPSDecrypt is returned when sops fails to decrypt a file.
+	errSOPSDecrypt = errors.New("sops failed to decrypt file")
+)
+
+// Matches a single segment of a dotted key path, such as .name or .name[0].
+var sopsExtractSegment = regexp.MustCompile(`\.([^.\[\]]+)((?:\[\d+\])*)`)
+
+type blindfoldSOPSResource struct {
+	timeout time.Duration
+	cache   *blindfoldCache
+}
+
+type blindfoldSOPSResourceModel struct {
+	ID                 types.String        `tfsdk:"id"`
+	Sealed             types.String        `tfsdk:"sealed"`
+	Location           types.String        `tfsdk:"location"`
+	SecretInfoJSON     types.String        `tfsdk:"secret_info_json"`
+	DecryptionProvider types.String        `tfsdk:"decryption_provider"`
+	StoreProvider      types.String        `tfsdk:"store_provider"`
+	Path               types.String        `tfsdk:"path"`
+	Extract            types.String        `tfsdk:"extract"`
+	AgeKeyFile         types.String        `tfsdk:"age_key_file"`
+	SOPS               types.String        `tfsdk:"sops"`
+	ContentSHA256      types.String        `tfsdk:"content_sha256"`
+	PolicyDocument     policyDocumentModel `tfsdk:"policy_document"`
+	Vesctl             types.String        `tfsdk:"vesctl"`
+	Triggers           types.Map           `tfsdk:"triggers"`
+	RotationPeriod     types.String        `tfsdk:"rotation_period"`
+	CreatedAt          types.String        `tfsdk:"created_at"`
+	ReplaceOnRotation  types.Bool          `tfsdk:"replace_on_rotation"`
+}
+
+// NewBlindfoldSOPSResource creates a new blindfold SOPS Terraform resource and returns a pointer to it.
+func NewBlindfoldSOPSResource() resource.Resource {
+	return &blindfoldSOPSResource{}
+}
+
+// Implement the Metadata function for Resource interface.
+func (r *blindfoldSOPSResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_blindfold_sops"
+}
+
+// Implement the Schema function for Resource interface. Blindfold SOPS resources are configured with the path to a
+// SOPS encrypted file, an optional key path to extract from the decrypted document, and a name+namespace reference to
+// a secret policy document. Definitive paths to sops and vesctl can be provided as options.
+func (r *blindfoldSOPSResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Generates a blindfolded secret from a [SOPS](https://getsops.io/) encrypted file.\n\n" +
+			"The file is decrypted in memory by the `sops` binary using the local age or PGP keys, and the decrypted " +
+			"content is **NOT** written to disk or added to Terraform state; a SHA-256 digest of the encrypted file " +
+			"is recorded instead so that the file will be blindfolded again when it changes.\n\n" +
+			"NOTE: The `sops` binary must be installed on the host that runs Terraform, either in PATH or at the " +
+			"path given by the `sops` attribute; it is checked when the configuration is validated. As the digest " +
+			"is of the encrypted file, any change to the ciphertext, such as `sops updatekeys`, `sops rotate`, or " +
+			"encrypting the same content again, will blindfold the secret again even if the decrypted content has " +
+			"not changed.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The computed resource identifier for the blindfolded secret.",
+				Computed:    true,
+			},
+			"sealed": schema.StringAttribute{
+				Description: "The base64 encoded, sealed data resulting from a blindfold.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"path": schema.StringAttribute{
+				Description: "The path of the SOPS encrypted file that will be decrypted and blindfolded.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"extract": schema.StringAttribute{
+				MarkdownDescription: "An optional key path, such as `.gcp.service_account` or `.keys[0]`, of a single " +
+					"value in the decrypted document to blindfold. If unspecified, the entire decrypted document will " +
+					"be blindfolded.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"age_key_file": schema.StringAttribute{
+				MarkdownDescription: "An optional path to a file containing age identities to decrypt the file. If " +
+					"unspecified, sops will use its default key sources, such as the `SOPS_AGE_KEY_FILE` " +
+					"environment variable or the local PGP keyring.",
+				Optional: true,
+			},
+			"sops": schema.StringAttribute{
+				MarkdownDescription: "The path to `sops` binary to use for decryption. If unspecified, the first sops " +
+					"binary found in PATH will be used. The binary must exist when the configuration is validated.",
+				Optional: true,
+			},
+			"content_sha256": schema.StringAttribute{
+				MarkdownDescription: "The hex encoded SHA-256 digest of the encrypted file content that was " +
+					"blindfolded. Any change to the encrypted file, including re-encryption of unchanged content, will " +
+					"blindfold the secret again.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"location": schema.StringAttribute{
+				MarkdownDescription: "The F5XC location of the sealed data, ready to use as the `location` of a " +
+					"`blindfold_secret_info` block.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"secret_info_json": schema.StringAttribute{
+				MarkdownDescription: "A JSON encoded F5XC `blindfold_secret_info` object that references the sealed " +
+					"data through its `location` field, with `decryption_provider` and `store_provider` fields set " +
+					"from the attributes of the same name.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"decryption_provider": schema.StringAttribute{
+				MarkdownDescription: "An optional name of the F5XC Secret Management Access object that will decrypt " +
+					"the secret, set as the `decryption_provider` field of `secret_info_json`. If unspecified the " +
+					"field is empty and F5XC uses its default. Changing the value does not blindfold the secret again.",
+				Optional: true,
+			},
+			"store_provider": schema.StringAttribute{
+				MarkdownDescription: "An optional name of the F5XC Secret Management Access object of the store that " +
+					"holds the sealed data, set as the `store_provider` field of `secret_info_json`. If unspecified " +
+					"the field is empty and F5XC reads the sealed data from `location`. Changing the value does not " +
+					"blindfold the secret again.",
+				Optional: true,
+			},
+			"policy_document": schema.SingleNestedAttribute{
+				Required: true,
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Description: "The name of the F5XC PolicyDocument to use for blindfold.",
+						Required:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"namespace": schema.StringAttribute{
+						Description: "The namespace of the F5XC PolicyDocument to use for blindfold.",
+						Required:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+				},
+			},
+			"vesctl": schema.StringAttribute{
+				MarkdownDescription: "The path to `vesctl` binary to use for blindfolding. If " +
+					"unspecified, the first vesctl binary found in PATH will be used",
+				Optional: true,
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "An arbitrary map of values that, when changed, will cause the secret to be " +
+					"blindfolded again.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplaceIf(
+						requiresReplaceIfTriggersChanged,
+						"Changing the triggers will blindfold the secret again.",
+						"Changing the triggers will blindfold the secret again.",
+					),
+				},
+			},
+			"rotation_period": schema.StringAttribute{
+				MarkdownDescription: "An optional duration, such as `720h`, after which the secret will be " +
+					"blindfolded again. The age of the secret is checked against `created_at` during plan.",
+				Optional: true,
+			},
+			"created_at": schema.StringAttribute{
+				Description: "The RFC3339 timestamp of when the secret was blindfolded.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"replace_on_rotation": schema.BoolAttribute{
+				MarkdownDescription: "If true, the default, the secret will be blindfolded again when the tenant's " +
+					"public key is rotated or the secret policy document is changed. If false, a warning will be " +
+					"reported instead.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+// Implement the Configure function for Resource interface.
+func (r *blindfoldSOPSResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*f5XCConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *f5XCConfig, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.timeout = cfg.timeout
+	r.cache = cfg.cache
+}
+
+// Implement the ValidateConfig function for ResourceWithValidateConfig interface. The sops binary must be available,
+// the extract key path must be convertible to a sops extract expression, and rotation_period must be a valid duration.
+func (r *blindfoldSOPSResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) { //nolint:gocritic // Provider interface passes ValidateConfigRequest by value.
+	resp.Diagnostics.Append(validateRotationPeriod(ctx, &req.Config)...)
+	var sops, extract types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("sops"), &sops)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("extract"), &extract)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !sops.IsUnknown() {
+		binary := sops.ValueString()
+		if binary == "" {
+			binary = defaultSOPSBinary
+		}
+		if _, err := exec.LookPath(binary); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("sops"),
+				"sops binary not found",
+				"The f5xc_blindfold_sops resource decrypts files with the sops binary, which must be installed on the "+
+					"host that runs Terraform. Install sops from https://getsops.io/, add it to PATH, or set the sops "+
+					"attribute to its path, unexpected error: "+err.Error(),
+			)
+		}
+	}
+	if extract.IsNull() || extract.IsUnknown() {
+		return
+	}
+	if _, err := sopsExtractExpression(extract.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("extract"),
+			"Invalid extract key path",
+			"The extract attribute must be a key path such as .gcp.service_account or .keys[0], unexpected error: "+
+				err.Error(),
+		)
+	}
+}
+
+// Implement the Create function for Resource interface. The SOPS encrypted file is decrypted in memory and the result
+// is blindfolded; any change in state that triggers the Create function will return a newly blindfolded secret value.
+func (r *blindfoldSOPSResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) { //nolint:gocritic // Provider interface passes CreateRequest by value.
+	tflog.Info(ctx, "Creating blindfold SOPS resource")
+	var model blindfoldSOPSResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "policy_doc_name", model.PolicyDocument.Name.ValueString())
+	ctx = tflog.SetField(ctx, "policy_doc_namespace", model.PolicyDocument.Namespace.ValueString())
+	ctx = tflog.SetField(ctx, "vesctl", model.Vesctl.ValueString())
+	ctx = tflog.SetField(ctx, "sops", model.SOPS.ValueString())
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error computing id",
+			"Failed to compute a new id for the resource, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	model.ID = types.StringValue(id.String())
+
+	encryptedPath := model.Path.ValueString()
+	tflog.Debug(ctx, "Computing encrypted file digest")
+	digest, err := fileSHA256(encryptedPath)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading encrypted file",
+			"Failed to compute digest of encrypted file at "+encryptedPath+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+	model.ContentSHA256 = types.StringValue(digest)
+
+	tflog.Debug(ctx, "Decrypting SOPS file")
+	sopsCtx, sopsCancel := context.WithTimeout(ctx, r.timeout)
+	defer sopsCancel()
+	plaintext, err := decryptSOPS(sopsCtx, model.SOPS.ValueString(), encryptedPath, model.Extract.ValueString(), model.AgeKeyFile.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error decrypting SOPS file",
+			"Failed to decrypt SOPS file at "+encryptedPath+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+	defer clear(plaintext)
+
+	tflog.Debug(ctx, "Fetching Public Key")
+	pubKey, err := r.cache.publicKey(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving PublicKey",
+			"Could not retrieve PublicKey, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Fetching Secret Policy Document")
+	policyDoc, err := r.cache.secretPolicyDocument(ctx, model.PolicyDocument.Name.ValueString(), model.PolicyDocument.Namespace.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving SecretPolicyDocument",
+			"Could not retrieve SecretPolicyDocument, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	rotation, err := newBlindfoldPrivateState(pubKey, policyDoc)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error recording PublicKey version",
+			"Failed to record PublicKey version and SecretPolicyDocument fingerprint, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(rotation.set(ctx, resp.Private)...)
+
+	tflog.Debug(ctx, "Executing blindfold")
+	clientCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	sealed, err := blindfold.Seal(clientCtx, model.Vesctl.ValueString(), plaintext, pubKey, policyDoc)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error blindfolding data",
+			"Failed to blindfold data, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	model.Sealed = types.StringValue(string(sealed))
+	location, secretInfo, err := blindfoldSecretInfo(model.Sealed.ValueString(), model.DecryptionProvider.ValueString(), model.StoreProvider.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error encoding blindfold_secret_info",
+			"Failed to encode blindfold_secret_info, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	model.Location = types.StringValue(location)
+	model.SecretInfoJSON = types.StringValue(secretInfo)
+	model.CreatedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Implement the Read function for Resource interface. The sealed value cannot be verified, but the public key version
+// and secret policy document that were used to blindfold the secret are compared to the current values in F5XC so that
+// the secret can be blindfolded again if either has changed.
+func (r *blindfoldSOPSResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) { //nolint:gocritic // Provider interface passes ReadRequest by value.
+	tflog.Info(ctx, "Reading blindfold SOPS resource")
+	var model blindfoldSOPSResourceModel
+	diags := req.State.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "policy_doc_name", model.PolicyDocument.Name.ValueString())
+	ctx = tflog.SetField(ctx, "policy_doc_namespace", model.PolicyDocument.Namespace.ValueString())
+
+	checkBlindfoldRotation(ctx, r.cache, []policyDocumentModel{model.PolicyDocument}, model.ReplaceOnRotation.ValueBool(), req.Private, resp)
+	checkBlindfoldCreatedAt(ctx, model.CreatedAt, resp)
+	checkBlindfoldSecretInfo(ctx, &req.State, resp)
+}
+
+// Implement the ModifyPlan function for ResourceWithModifyPlan interface. The resource will be replaced if Read has
+// detected that the public key or secret policy document has changed since the secret was blindfolded, or if the
+// encrypted file no longer matches the digest recorded when it was blindfolded. The file is not decrypted during plan.
+func (r *blindfoldSOPSResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) { //nolint:gocritic // Provider interface passes ModifyPlanRequest by value.
+	planBlindfoldRotation(ctx, &req, resp)
+	planBlindfoldSecretInfo(ctx, &req, resp)
+	planBlindfoldRotationPeriod(ctx, &req, resp)
+	// Nothing more to do when the resource is being created or destroyed; the digest will be computed by Create.
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	var encryptedPath, recorded types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("path"), &encryptedPath)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("content_sha256"), &recorded)...)
+	if resp.Diagnostics.HasError() || encryptedPath.IsUnknown() {
+		return
+	}
+	digest, err := fileSHA256(encryptedPath.ValueString())
+	if err != nil {
+		// The file may be generated by another resource during apply, so leave any failure for Create to report.
+		tflog.Debug(ctx, "Unable to compute encrypted file digest during plan", map[string]any{"error": err.Error()})
+		return
+	}
+	if recorded.ValueString() == digest {
+		return
+	}
+	tflog.Info(ctx, "Encrypted file content has changed, requiring replacement")
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_sha256"), types.StringValue(digest))...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("sealed"), types.StringUnknown())...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("content_sha256"))
+}
+
+// Implement the Update function for Resource interface. Blindfold resources do not create any state to update, so this
+// function sets post-update state to the same values as present in the prior plan.
+func (r *blindfoldSOPSResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) { //nolint:gocritic // Provider interface passes UpdateRequest by value.
+	tflog.Info(ctx, "Updating blindfold SOPS resource")
+	var model blindfoldSOPSResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Implement the Delete function for Resource interface. Blindfold resources do not create any state to clean up, so this
+// function does nothing. Terraform state will be deleted as long as the function does not add diagnostics to the response.
+func (r *blindfoldSOPSResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) { //nolint:gocritic // Provider interface passes DeleteRequest by value.
+}
+
+// Decrypts the SOPS encrypted file at encryptedPath with the sops binary, returning the decrypted content, or the
+// single value at the extract key path, from memory. If ageKeyFile is not empty it is used as the source of age
+// identities.
+func decryptSOPS(ctx context.Context, sops, encryptedPath, extract, ageKeyFile string) ([]byte, error) {
+	if sops == "" {
+		sops = defaultSOPSBinary
+	}
+	args := []string{"--decrypt"}
+	if extract != "" {
+		expression, err := sopsExtractExpression(extract)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "--extract", expression)
+	}
+	args = append(args, encryptedPath)
+	cmd := exec.CommandContext(ctx, sops, args...)
+	cmd.Env = os.Environ()
+	if ageKeyFile != "" {
+		cmd.Env = append(cmd.Env, sopsAgeKeyFileEnv+"="+ageKeyFile)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	plaintext, err := cmd.Output()
+	if err != nil {
+		clear(plaintext)
+		return nil, fmt.Errorf("%w: %w: %s", errSOPSDecrypt, err, strings.TrimSpace(stderr.String()))
+	}
+	return plaintext, nil
+}
+
+// Converts a dotted key path, such as .gcp.service_account or .keys[0], to a sops extract expression such as
+// ["gcp"]["service_account"]. Expressions that are already in sops syntax are returned unchanged.
+func sopsExtractExpression(keyPath string) (string, error) {
+	if strings.HasPrefix(keyPath, "[") {
+		return keyPath, nil
+	}
+	matches := sopsExtractSegment.FindAllStringSubmatchIndex(keyPath, -1)
+	var builder strings.Builder
+	end := 0
+	for _, match := range matches {
+		if match[0] != end {
+			break
+		}
+		end = match[1]
+		fmt.Fprintf(&builder, "[%q]%s", keyPath[match[2]:match[3]], keyPath[match[4]:match[5]])
+	}
+	if end == 0 || end != len(keyPath) {
+		return "", fmt.Errorf("%w: %s", errInvalidSOPSExtract, keyPath)
+	}
+	return builder.String(), nil
+}
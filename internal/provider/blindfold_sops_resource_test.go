@@ -0,0 +1,130 @@
+package provider_test
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccBlindfoldSOPSResource(t *testing.T) {
+	t.Parallel()
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
+	// The resource requires sops, so a missing binary must fail the acceptance test rather than skip it.
+	for _, binary := range []string{"sops", "age-keygen"} {
+		if _, err := exec.LookPath(binary); err != nil {
+			t.Fatalf("%s binary is required by the SOPS acceptance test but was not found in PATH: %v", binary, err)
+		}
+	}
+	tmpDir := t.TempDir()
+	keyFile := filepath.Join(tmpDir, "age.key")
+	if err := exec.Command("age-keygen", "-o", keyFile).Run(); err != nil {
+		t.Fatalf("failed to generate age key: %v", err)
+	}
+	recipient, err := exec.Command("age-keygen", "-y", keyFile).Output()
+	if err != nil {
+		t.Fatalf("failed to get age recipient: %v", err)
+	}
+	encryptedFile := filepath.Join(tmpDir, "secrets.enc.yaml")
+	encrypt := exec.Command("sops", "--encrypt", "--age", strings.TrimSpace(string(recipient)), "--input-type", "yaml", "--output-type", "yaml", "/dev/stdin")
+	encrypt.Stdin = bytes.NewBufferString("gcp:\n  service_account: This is a plaintext service account\nother: This is another secret\n")
+	encrypted, err := encrypt.Output()
+	if err != nil {
+		t.Fatalf("failed to encrypt SOPS file: %v", err)
+	}
+	if err := os.WriteFile(encryptedFile, encrypted, 0o600); err != nil {
+		t.Fatalf("failed to write SOPS file: %v", err)
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "f5xc_blindfold_sops" "test" {
+	path = "` + encryptedFile + `"
+	age_key_file = "` + keyFile + `"
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("f5xc_blindfold_sops.test", "id"),
+					resource.TestCheckResourceAttr("f5xc_blindfold_sops.test", "path", encryptedFile),
+					resource.TestCheckResourceAttrSet("f5xc_blindfold_sops.test", "content_sha256"),
+					resource.TestCheckResourceAttr("f5xc_blindfold_sops.test", "replace_on_rotation", "true"),
+					resource.TestMatchResourceAttr("f5xc_blindfold_sops.test", "location", regexp.MustCompile(`^string:///.+`)),
+					resource.TestCheckResourceAttrSet("f5xc_blindfold_sops.test", "sealed"),
+				),
+			},
+			// Extracting a single value must blindfold the secret again.
+			{
+				Config: providerConfig + `
+resource "f5xc_blindfold_sops" "test" {
+	path = "` + encryptedFile + `"
+	extract = ".gcp.service_account"
+	age_key_file = "` + keyFile + `"
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("f5xc_blindfold_sops.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("f5xc_blindfold_sops.test", "extract", ".gcp.service_account"),
+					resource.TestCheckResourceAttrSet("f5xc_blindfold_sops.test", "sealed"),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "f5xc_blindfold_sops" "test" {
+	path = "` + encryptedFile + `"
+	extract = "gcp"
+	age_key_file = "` + keyFile + `"
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid extract key path`),
+			},
+		},
+	})
+}
+
+func TestAccBlindfoldSOPSResourceMissingBinary(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "f5xc_blindfold_sops" "test" {
+	path = "secrets.enc.yaml"
+	sops = "` + filepath.Join(t.TempDir(), "sops") + `"
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+}
+`,
+				ExpectError: regexp.MustCompile(`sops binary not found`),
+			},
+		},
+	})
+}
@@ -0,0 +1,469 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/memes/f5xc/blindfold"
+)
+
+var (
+	_ resource.Resource                   = &blindfoldTemplateResource{}
+	_ resource.ResourceWithModifyPlan     = &blindfoldTemplateResource{}
+	_ resource.ResourceWithConfigure      = &blindfoldTemplateResource{}
+	_ resource.ResourceWithValidateConfig = &blindfoldTemplateResource{}
+)
+
+// errTemplateUnavailable is returned when the template or its variables cannot be retrieved from config, such as when
+// they are unknown during plan.
+var errTemplateUnavailable = errors.New("template or vars are not available")
+
+// The functions available to templates, named after the equivalent Terraform functions.
+var blindfoldTemplateFuncs = template.FuncMap{ //nolint:gochecknoglobals // Shared between validation and rendering.
+	"base64encode": func(s string) string {
+		return base64.StdEncoding.EncodeToString([]byte(s))
+	},
+	"jsonencode": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		return string(data), nil
+	},
+	"indent": func(spaces int, s string) string {
+		return strings.ReplaceAll(s, "\n", "\n"+strings.Repeat(" ", spaces))
+	},
+}
+
+type blindfoldTemplateResource struct {
+	timeout time.Duration
+	cache   *blindfoldCache
+}
+
+type blindfoldTemplateResourceModel struct {
+	ID                 types.String        `tfsdk:"id"`
+	Sealed             types.String        `tfsdk:"sealed"`
+	Location           types.String        `tfsdk:"location"`
+	SecretInfoJSON     types.String        `tfsdk:"secret_info_json"`
+	DecryptionProvider types.String        `tfsdk:"decryption_provider"`
+	StoreProvider      types.String        `tfsdk:"store_provider"`
+	Template           types.String        `tfsdk:"template"`
+	Vars               types.Map           `tfsdk:"vars"`
+	RenderedHash       types.String        `tfsdk:"rendered_hash"`
+	PolicyDocument     policyDocumentModel `tfsdk:"policy_document"`
+	Vesctl             types.String        `tfsdk:"vesctl"`
+	ReplaceOnRotation  types.Bool          `tfsdk:"replace_on_rotation"`
+}
+
+// NewBlindfoldTemplateResource creates a new blindfold template Terraform resource and returns a pointer to it.
+func NewBlindfoldTemplateResource() resource.Resource {
+	return &blindfoldTemplateResource{}
+}
+
+// Implement the Metadata function for Resource interface.
+func (r *blindfoldTemplateResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_blindfold_template"
+}
+
+// Implement the Schema function for Resource interface. Blindfold template resources accept a Go template and a
+// write-only map of variables, and a name+namespace reference to a secret policy document. The rendered template is
+// blindfolded directly, and only a salted hash of it is stored in state.
+func (r *blindfoldTemplateResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Generates a blindfolded secret by rendering a [Go template](https://pkg.go.dev/text/template) " +
+			"with a map of sensitive variables.\n\n" +
+			"The rendered template is blindfolded directly and is never stored in Terraform plan or state; a salted " +
+			"hash of the rendered template is stored instead so that a change to the template or variables will " +
+			"blindfold it again. The template is rendered during plan, so errors such as a reference to a variable " +
+			"that is not set are reported before apply.\n\n" +
+			"Only Go templates are supported; Terraform templates in the syntax of `templatefile` cannot be rendered " +
+			"by the provider.\n\n" +
+			"NOTE: The `vars` attribute is write-only and requires Terraform 1.11 or later.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The computed resource identifier for the blindfolded secret.",
+				Computed:    true,
+			},
+			"sealed": schema.StringAttribute{
+				Description: "The base64 encoded, sealed data resulting from a blindfold.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"template": schema.StringAttribute{
+				MarkdownDescription: "The Go template to render. Variables are available as `{{ .name }}`, and the " +
+					"`base64encode`, `jsonencode`, and `indent` functions can be used to format them.",
+				Required: true,
+			},
+			"vars": schema.MapAttribute{
+				MarkdownDescription: "A map of variables to render the template with, as a write-only value that is " +
+					"never stored in Terraform plan or state.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+			},
+			"rendered_hash": schema.StringAttribute{
+				Description: "A salted SHA-256 hash of the rendered template that was blindfolded, used to detect " +
+					"changes to the template or variables without storing them in state.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"location": schema.StringAttribute{
+				MarkdownDescription: "The F5XC location of the sealed data, ready to use as the `location` of a " +
+					"`blindfold_secret_info` block.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"secret_info_json": schema.StringAttribute{
+				MarkdownDescription: "A JSON encoded F5XC `blindfold_secret_info` object that references the sealed " +
+					"data through its `location` field, with `decryption_provider` and `store_provider` fields set " +
+					"from the attributes of the same name.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"decryption_provider": schema.StringAttribute{
+				MarkdownDescription: "An optional name of the F5XC Secret Management Access object that will decrypt " +
+					"the secret, set as the `decryption_provider` field of `secret_info_json`. If unspecified the " +
+					"field is empty and F5XC uses its default. Changing the value does not blindfold the secret again.",
+				Optional: true,
+			},
+			"store_provider": schema.StringAttribute{
+				MarkdownDescription: "An optional name of the F5XC Secret Management Access object of the store that " +
+					"holds the sealed data, set as the `store_provider` field of `secret_info_json`. If unspecified " +
+					"the field is empty and F5XC reads the sealed data from `location`. Changing the value does not " +
+					"blindfold the secret again.",
+				Optional: true,
+			},
+			"policy_document": schema.SingleNestedAttribute{
+				Required: true,
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Description: "The name of the F5XC PolicyDocument to use for blindfold.",
+						Required:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"namespace": schema.StringAttribute{
+						Description: "The namespace of the F5XC PolicyDocument to use for blindfold.",
+						Required:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+				},
+			},
+			"vesctl": schema.StringAttribute{
+				MarkdownDescription: "The path to `vesctl` binary to use for blindfolding. If " +
+					"unspecified, the first vesctl binary found in PATH will be used",
+				Optional: true,
+			},
+			"replace_on_rotation": schema.BoolAttribute{
+				MarkdownDescription: "If true, the default, the secret will be blindfolded again when the tenant's " +
+					"public key is rotated or the secret policy document is changed. If false, a warning will be " +
+					"reported instead.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+// Implement the Configure function for Resource interface.
+func (r *blindfoldTemplateResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*f5XCConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *f5XCConfig, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.timeout = cfg.timeout
+	r.cache = cfg.cache
+}
+
+// Implement the ValidateConfig function for ResourceWithValidateConfig interface. The template must be a valid Go
+// template.
+func (r *blindfoldTemplateResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) { //nolint:gocritic // Provider interface passes ValidateConfigRequest by value.
+	var text types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("template"), &text)...)
+	if resp.Diagnostics.HasError() || text.IsNull() || text.IsUnknown() {
+		return
+	}
+	if _, err := parseBlindfoldTemplate(text.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("template"),
+			"Invalid template",
+			"The template could not be parsed, unexpected error: "+err.Error(),
+		)
+	}
+}
+
+// Implement the Create function for Resource interface. The template is rendered in memory and the result is
+// blindfolded; any change in state that triggers the Create function will return a newly blindfolded secret value.
+func (r *blindfoldTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) { //nolint:gocritic // Provider interface passes CreateRequest by value.
+	tflog.Info(ctx, "Creating blindfold template resource")
+	var model blindfoldTemplateResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "policy_doc_name", model.PolicyDocument.Name.ValueString())
+	ctx = tflog.SetField(ctx, "policy_doc_namespace", model.PolicyDocument.Namespace.ValueString())
+	ctx = tflog.SetField(ctx, "vesctl", model.Vesctl.ValueString())
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error computing id",
+			"Failed to compute a new id for the resource, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	model.ID = types.StringValue(id.String())
+
+	tflog.Debug(ctx, "Rendering template")
+	rendered, err := renderBlindfoldTemplate(ctx, &req.Config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error rendering template",
+			"Failed to render template, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	defer clear(rendered)
+	hash, err := newPlaintextHash(string(rendered))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error hashing rendered template",
+			"Failed to compute a salted hash of the rendered template, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	model.RenderedHash = types.StringValue(hash)
+	model.Vars = types.MapNull(types.StringType)
+
+	tflog.Debug(ctx, "Fetching Public Key")
+	pubKey, err := r.cache.publicKey(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving PublicKey",
+			"Could not retrieve PublicKey, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Fetching Secret Policy Document")
+	policyDoc, err := r.cache.secretPolicyDocument(ctx, model.PolicyDocument.Name.ValueString(), model.PolicyDocument.Namespace.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving SecretPolicyDocument",
+			"Could not retrieve SecretPolicyDocument, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	rotation, err := newBlindfoldPrivateState(pubKey, policyDoc)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error recording PublicKey version",
+			"Failed to record PublicKey version and SecretPolicyDocument fingerprint, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(rotation.set(ctx, resp.Private)...)
+
+	tflog.Debug(ctx, "Executing blindfold")
+	clientCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	sealed, err := blindfold.Seal(clientCtx, model.Vesctl.ValueString(), rendered, pubKey, policyDoc)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error blindfolding data",
+			"Failed to blindfold data, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	model.Sealed = types.StringValue(string(sealed))
+	location, secretInfo, err := blindfoldSecretInfo(model.Sealed.ValueString(), model.DecryptionProvider.ValueString(), model.StoreProvider.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error encoding blindfold_secret_info",
+			"Failed to encode blindfold_secret_info, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	model.Location = types.StringValue(location)
+	model.SecretInfoJSON = types.StringValue(secretInfo)
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Implement the Read function for Resource interface. The sealed value cannot be verified, but the public key version
+// and secret policy document that were used to blindfold the secret are compared to the current values in F5XC so that
+// the secret can be blindfolded again if either has changed.
+func (r *blindfoldTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) { //nolint:gocritic // Provider interface passes ReadRequest by value.
+	tflog.Info(ctx, "Reading blindfold template resource")
+	var model blindfoldTemplateResourceModel
+	diags := req.State.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "policy_doc_name", model.PolicyDocument.Name.ValueString())
+	ctx = tflog.SetField(ctx, "policy_doc_namespace", model.PolicyDocument.Namespace.ValueString())
+
+	checkBlindfoldRotation(ctx, r.cache, []policyDocumentModel{model.PolicyDocument}, model.ReplaceOnRotation.ValueBool(), req.Private, resp)
+	checkBlindfoldSecretInfo(ctx, &req.State, resp)
+}
+
+// Implement the ModifyPlan function for ResourceWithModifyPlan interface. The template is rendered during plan so that
+// errors, such as a reference to a variable that is not set, are reported before apply. The resource will be replaced if
+// Read has detected that the public key or secret policy document has changed since the secret was blindfolded, or if
+// the rendered template no longer matches the salted hash recorded when it was blindfolded.
+func (r *blindfoldTemplateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) { //nolint:gocritic // Provider interface passes ModifyPlanRequest by value.
+	planBlindfoldRotation(ctx, &req, resp)
+	planBlindfoldSecretInfo(ctx, &req, resp)
+	// Nothing more to do when the resource is being destroyed.
+	if resp.Diagnostics.HasError() || req.Plan.Raw.IsNull() {
+		return
+	}
+	rendered, err := renderBlindfoldTemplate(ctx, &req.Config)
+	switch {
+	case errors.Is(err, errTemplateUnavailable) && req.State.Raw.IsNull():
+		tflog.Debug(ctx, "Template or variables are unknown during plan, unable to render template")
+		return
+	case errors.Is(err, errTemplateUnavailable):
+		// The template or variables are unknown until apply, so changes cannot be ruled out.
+		tflog.Info(ctx, "Template or variables are unknown during plan, requiring replacement")
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("rendered_hash"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("sealed"), types.StringUnknown())...)
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("rendered_hash"))
+		return
+	case err != nil:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("template"),
+			"Error rendering template",
+			"Failed to render template, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	defer clear(rendered)
+	// The hash will be computed by Create when the resource is being created.
+	if req.State.Raw.IsNull() {
+		return
+	}
+	var recorded types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("rendered_hash"), &recorded)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	matches, err := plaintextHashMatches(recorded.ValueString(), string(rendered))
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("rendered_hash"),
+			"Unable to compare rendered template with recorded hash",
+			"The recorded hash could not be parsed and the secret will be blindfolded again, unexpected error: "+
+				err.Error(),
+		)
+	}
+	if matches {
+		return
+	}
+	tflog.Info(ctx, "Rendered template has changed, requiring replacement")
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("rendered_hash"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("sealed"), types.StringUnknown())...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("rendered_hash"))
+}
+
+// Implement the Update function for Resource interface. Blindfold resources do not create any state to update, so this
+// function sets post-update state to the same values as present in the prior plan.
+func (r *blindfoldTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) { //nolint:gocritic // Provider interface passes UpdateRequest by value.
+	tflog.Info(ctx, "Updating blindfold template resource")
+	var model blindfoldTemplateResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	model.Vars = types.MapNull(types.StringType)
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Implement the Delete function for Resource interface. Blindfold resources do not create any state to clean up, so this
+// function does nothing. Terraform state will be deleted as long as the function does not add diagnostics to the response.
+func (r *blindfoldTemplateResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) { //nolint:gocritic // Provider interface passes DeleteRequest by value.
+}
+
+// Parses the template text. Templates fail to render when they reference a variable that is not set.
+func parseBlindfoldTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("blindfold").Funcs(blindfoldTemplateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// Renders the template of the config with its vars. Write-only values are never included in the plan and must be
+// retrieved from config.
+func renderBlindfoldTemplate(ctx context.Context, config *tfsdk.Config) ([]byte, error) {
+	var text types.String
+	var vars types.Map
+	if diags := config.GetAttribute(ctx, path.Root("template"), &text); diags.HasError() {
+		return nil, errTemplateUnavailable
+	}
+	if diags := config.GetAttribute(ctx, path.Root("vars"), &vars); diags.HasError() {
+		return nil, errTemplateUnavailable
+	}
+	if text.IsUnknown() || vars.IsUnknown() {
+		return nil, errTemplateUnavailable
+	}
+	values := map[string]string{}
+	if diags := vars.ElementsAs(ctx, &values, false); diags.HasError() {
+		return nil, errTemplateUnavailable
+	}
+	tmpl, err := parseBlindfoldTemplate(text.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, values); err != nil {
+		return nil, fmt.Errorf("failed to render template: %w", err)
+	}
+	return rendered.Bytes(), nil
+}
@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Returns a blindfold template config with the template and vars values, and all other attributes null.
+func testBlindfoldTemplateConfig(t *testing.T, text, vars tftypes.Value) *tfsdk.Config {
+	t.Helper()
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	NewBlindfoldTemplateResource().Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object) //nolint:forcetypeassert // Schemas are always objects.
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	values["template"] = text
+	values["vars"] = vars
+	return &tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(objectType, values),
+	}
+}
+
+func TestRenderBlindfoldTemplate(t *testing.T) {
+	t.Parallel()
+	varsType := tftypes.Map{ElementType: tftypes.String}
+	vars := func(values map[string]string) tftypes.Value {
+		elements := make(map[string]tftypes.Value, len(values))
+		for key, value := range values {
+			elements[key] = tftypes.NewValue(tftypes.String, value)
+		}
+		return tftypes.NewValue(varsType, elements)
+	}
+	tests := []struct {
+		name          string
+		template      tftypes.Value
+		vars          tftypes.Value
+		expected      string
+		expectedError bool
+		unavailable   bool
+	}{
+		{
+			name:     "variable",
+			template: tftypes.NewValue(tftypes.String, "user={{ .user }}"),
+			vars:     vars(map[string]string{"user": "admin"}),
+			expected: "user=admin",
+		},
+		{
+			name:     "no variables",
+			template: tftypes.NewValue(tftypes.String, "This is a test"),
+			vars:     tftypes.NewValue(varsType, nil),
+			expected: "This is a test",
+		},
+		{
+			name:     "base64encode",
+			template: tftypes.NewValue(tftypes.String, "{{ base64encode .password }}"),
+			vars:     vars(map[string]string{"password": "This is a test"}),
+			expected: "VGhpcyBpcyBhIHRlc3Q=",
+		},
+		{
+			name:     "jsonencode",
+			template: tftypes.NewValue(tftypes.String, `{"password":{{ jsonencode .password }}}`),
+			vars:     vars(map[string]string{"password": "\"quoted\"\n"}),
+			expected: `{"password":"\"quoted\"\n"}`,
+		},
+		{
+			name:     "indent",
+			template: tftypes.NewValue(tftypes.String, "key:\n  {{ indent 2 .key }}"),
+			vars:     vars(map[string]string{"key": "first\nsecond"}),
+			expected: "key:\n  first\n  second",
+		},
+		{
+			name:          "missing variable",
+			template:      tftypes.NewValue(tftypes.String, "{{ .user }}:{{ .password }}"),
+			vars:          vars(map[string]string{"user": "admin"}),
+			expectedError: true,
+		},
+		{
+			name:          "syntax error",
+			template:      tftypes.NewValue(tftypes.String, "{{ .user"),
+			vars:          vars(map[string]string{"user": "admin"}),
+			expectedError: true,
+		},
+		{
+			name:          "unknown template",
+			template:      tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			vars:          vars(map[string]string{"user": "admin"}),
+			expectedError: true,
+			unavailable:   true,
+		},
+		{
+			name:          "unknown vars",
+			template:      tftypes.NewValue(tftypes.String, "{{ .user }}"),
+			vars:          tftypes.NewValue(varsType, tftypes.UnknownValue),
+			expectedError: true,
+			unavailable:   true,
+		},
+		{
+			name:     "unknown variable",
+			template: tftypes.NewValue(tftypes.String, "{{ .user }}"),
+			vars: tftypes.NewValue(varsType, map[string]tftypes.Value{
+				"user": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			}),
+			expectedError: true,
+			unavailable:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			config := testBlindfoldTemplateConfig(t, test.template, test.vars)
+			result, err := renderBlindfoldTemplate(context.Background(), config)
+			switch {
+			case (err != nil) != test.expectedError:
+				t.Errorf("expected error to be %t, got %v", test.expectedError, err)
+			case errors.Is(err, errTemplateUnavailable) != test.unavailable:
+				t.Errorf("expected template to be unavailable to be %t, got %v", test.unavailable, err)
+			case string(result) != test.expected:
+				t.Errorf("expected %q, got %q", test.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccBlindfoldTemplateResource(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "f5xc_blindfold_template" "test" {
+	template = "{\"user\":{{ jsonencode .user }},\"password\":{{ jsonencode .password }}}"
+	vars = {
+		user     = "admin"
+		password = "This is a test"
+	}
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("f5xc_blindfold_template.test", "id"),
+					resource.TestCheckNoResourceAttr("f5xc_blindfold_template.test", "vars"),
+					resource.TestCheckResourceAttrSet("f5xc_blindfold_template.test", "rendered_hash"),
+					resource.TestMatchResourceAttr("f5xc_blindfold_template.test", "location", regexp.MustCompile(`^string:///.+`)),
+					resource.TestCheckResourceAttrSet("f5xc_blindfold_template.test", "sealed"),
+				),
+			},
+			// Changing a variable must render and blindfold the template again.
+			{
+				Config: providerConfig + `
+resource "f5xc_blindfold_template" "test" {
+	template = "{\"user\":{{ jsonencode .user }},\"password\":{{ jsonencode .password }}}"
+	vars = {
+		user     = "admin"
+		password = "This is another test"
+	}
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("f5xc_blindfold_template.test", plancheck.ResourceActionReplace),
+					},
+				},
+			},
+			{
+				Config: providerConfig + `
+resource "f5xc_blindfold_template" "test" {
+	template = "{{ .user"
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid template`),
+			},
+			// A reference to a variable that is not set must fail during plan.
+			{
+				Config: providerConfig + `
+resource "f5xc_blindfold_template" "test" {
+	template = "{\"user\":{{ jsonencode .user }},\"password\":{{ jsonencode .password }}}"
+	vars = {
+		user = "admin"
+	}
+	policy_document = {
+		name = "ves-io-allow-volterra"
+		namespace = "shared"
+	}
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Error rendering template`),
+			},
+		},
+	})
+}
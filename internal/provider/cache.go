@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/memes/f5xc"
+	"golang.org/x/sync/singleflight"
+)
+
+var (
+	// errPublicKeyNotFound is returned when F5XC does not return a public key for the tenant.
+	errPublicKeyNotFound = errors.New("PublicKey was not found for this account")
+	// errSecretPolicyDocumentNotFound is returned when F5XC does not return the requested secret policy document.
+	errSecretPolicyDocumentNotFound = errors.New("SecretPolicyDocument was not found; check the assigned values for name and namespace")
+	// errUnexpectedCacheValue is returned if a cached value does not have the expected type.
+	errUnexpectedCacheValue = errors.New("unexpected cache value")
+)
+
+// The cache key used for the tenant public key.
+const publicKeyCacheKey = "public_key"
+
+// blindfoldCache shares the tenant public key and secret policy documents between all resources that are managed
+// during a single Terraform operation. Concurrent requests for the same value are de-duplicated so that only a single
+// API call is made, and successful results are reused until the TTL expires.
+type blindfoldCache struct {
+	client  *http.Client
+	timeout time.Duration
+	ttl     time.Duration
+	group   singleflight.Group
+	mu      sync.Mutex
+	entries map[string]blindfoldCacheEntry
+}
+
+type blindfoldCacheEntry struct {
+	value   any
+	expires time.Time
+}
+
+// Returns a new blindfoldCache that will use the supplied HTTP client and timeout for API calls, and will retain
+// results for ttl. A zero ttl disables caching of results, but concurrent requests are still de-duplicated.
+func newBlindfoldCache(client *http.Client, timeout, ttl time.Duration) *blindfoldCache {
+	return &blindfoldCache{
+		client:  client,
+		timeout: timeout,
+		ttl:     ttl,
+		entries: map[string]blindfoldCacheEntry{},
+	}
+}
+
+// Returns the tenant public key, fetching it from F5XC if there is not a current value in the cache.
+func (c *blindfoldCache) publicKey(ctx context.Context) (*f5xc.PublicKey, error) {
+	value, err := c.get(ctx, publicKeyCacheKey, func(clientCtx context.Context) (any, error) {
+		pubKey, err := f5xc.GetPublicKey(clientCtx, c.client, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get public key: %w", err)
+		}
+		if pubKey == nil {
+			return nil, errPublicKeyNotFound
+		}
+		return pubKey, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	pubKey, ok := value.(*f5xc.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected cached public key type %T", errUnexpectedCacheValue, value)
+	}
+	return pubKey, nil
+}
+
+// Returns the named secret policy document, fetching it from F5XC if there is not a current value in the cache.
+func (c *blindfoldCache) secretPolicyDocument(ctx context.Context, name, namespace string) (*f5xc.SecretPolicyDocument, error) {
+	value, err := c.get(ctx, "policy_document/"+namespace+"/"+name, func(clientCtx context.Context) (any, error) {
+		policyDoc, err := f5xc.GetSecretPolicyDocument(clientCtx, c.client, name, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get secret policy document: %w", err)
+		}
+		if policyDoc == nil {
+			return nil, errSecretPolicyDocumentNotFound
+		}
+		return policyDoc, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	policyDoc, ok := value.(*f5xc.SecretPolicyDocument)
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected cached secret policy document type %T", errUnexpectedCacheValue, value)
+	}
+	return policyDoc, nil
+}
+
+// Returns the cached value for key if it has not expired, or calls fetch to retrieve the value. Concurrent calls for the
+// same key share the result of a single call to fetch, which is given a context that is bounded by the cache timeout
+// but is not cancelled when the context of the first caller is done.
+func (c *blindfoldCache) get(ctx context.Context, key string, fetch func(context.Context) (any, error)) (any, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		tflog.Debug(ctx, "Using cached value", map[string]any{"cache_key": key})
+		return entry.value, nil
+	}
+
+	ch := c.group.DoChan(key, func() (any, error) {
+		tflog.Debug(ctx, "Fetching value for cache", map[string]any{"cache_key": key})
+		clientCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.timeout)
+		defer cancel()
+		value, err := fetch(clientCtx)
+		if err != nil {
+			return nil, err
+		}
+		if c.ttl > 0 {
+			c.mu.Lock()
+			c.entries[key] = blindfoldCacheEntry{
+				value:   value,
+				expires: time.Now().Add(c.ttl),
+			}
+			c.mu.Unlock()
+		}
+		return value, nil
+	})
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("cancelled while waiting for %s: %w", key, ctx.Err())
+	case result := <-ch:
+		return result.Val, result.Err
+	}
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBlindfoldCacheGet(t *testing.T) {
+	t.Parallel()
+	cache := newBlindfoldCache(nil, time.Second, time.Minute)
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fetch := func(_ context.Context) (any, error) {
+		calls.Add(1)
+		<-release
+		return "value", nil
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := cache.get(t.Context(), "key", fetch)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if value != "value" {
+				t.Errorf("expected value, got %v", value)
+			}
+		}()
+	}
+	// Give the goroutines a chance to block on the shared fetch before releasing it.
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if _, err := cache.get(t.Context(), "key", fetch); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected a single fetch, got %d", got)
+	}
+}
+
+func TestBlindfoldCacheGetNoTTL(t *testing.T) {
+	t.Parallel()
+	cache := newBlindfoldCache(nil, time.Second, 0)
+	var calls atomic.Int32
+	fetch := func(_ context.Context) (any, error) {
+		calls.Add(1)
+		return "value", nil
+	}
+
+	for range 3 {
+		if _, err := cache.get(t.Context(), "key", fetch); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected 3 fetches when caching is disabled, got %d", got)
+	}
+}
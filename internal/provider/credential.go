@@ -0,0 +1,231 @@
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/crypto/pkcs12" //nolint:staticcheck // Only the certificate is read, which the deprecated decoder supports.
+)
+
+// errCertificateNotFound is returned when a PEM or PKCS#12 credential does not contain a client certificate.
+var errCertificateNotFound = errors.New("client certificate not found")
+
+// whoAmIJSON mirrors the subset of the F5XC whoami response that identifies the authenticated tenant and user.
+type whoAmIJSON struct {
+	Tenant string `json:"tenant"`
+	Email  string `json:"email"`
+	Name   string `json:"name"`
+}
+
+// Returns the x509 client certificate of the chosen authentication method, or nil if the method does not use a
+// certificate.
+func (a *f5XCAuth) certificate() (*x509.Certificate, error) {
+	switch a.method {
+	case authMethodP12Content:
+		data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(a.p12Content.value))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode PKCS#12 content: %w", err)
+		}
+		return parsePKCS12Certificate(data, a.p12Password.value)
+	case authMethodP12File:
+		data, err := os.ReadFile(a.p12File.value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read PKCS#12 file: %w", err)
+		}
+		return parsePKCS12Certificate(data, a.p12Password.value)
+	case authMethodCertKey:
+		data, err := readPEM(a.cert.value)
+		if err != nil {
+			return nil, err
+		}
+		return parsePEMCertificate(data)
+	}
+	// API tokens do not have a certificate.
+	return nil, nil
+}
+
+// Returns the first client certificate in the PKCS#12 data, ignoring any CA certificates that are bundled with it.
+func parsePKCS12Certificate(data []byte, password string) (*x509.Certificate, error) {
+	blocks, err := pkcs12.ToPEM(data, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode PKCS#12 data: %w", err)
+	}
+	for _, block := range blocks {
+		if block.Type != "CERTIFICATE" {
+			clear(block.Bytes)
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse PKCS#12 certificate: %w", err)
+		}
+		if !cert.IsCA {
+			return cert, nil
+		}
+	}
+	return nil, errCertificateNotFound
+}
+
+// Returns the first certificate in the PEM encoded data.
+func parsePEMCertificate(data []byte) (*x509.Certificate, error) {
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse PEM certificate: %w", err)
+		}
+		return cert, nil
+	}
+	return nil, errCertificateNotFound
+}
+
+// Returns the tenant named in the subject of an F5XC API certificate, which is the organization if present or the
+// domain of an email style common name, or an empty string if the subject does not identify a tenant.
+func certificateTenant(cert *x509.Certificate) string {
+	if len(cert.Subject.Organization) > 0 {
+		return cert.Subject.Organization[0]
+	}
+	if _, tenant, found := strings.Cut(cert.Subject.CommonName, "@"); found {
+		return tenant
+	}
+	return ""
+}
+
+// Returns the tenant of an F5XC API URL, which is the first label of a tenant console hostname such as
+// acme.console.ves.volterra.io, or an empty string if the hostname is not tenant specific.
+func urlTenant(apiURL string) string {
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return ""
+	}
+	labels := strings.Split(u.Hostname(), ".")
+	if len(labels) < 3 {
+		return ""
+	}
+	return labels[0]
+}
+
+// Returns true if a and b name the same tenant, allowing either to be the short tenant name that prefixes the full
+// tenant identifier, e.g. acme and acme-abcdefgh.
+func sameTenant(a, b string) bool {
+	return strings.EqualFold(a, b) ||
+		strings.HasPrefix(strings.ToLower(a), strings.ToLower(b)+"-") ||
+		strings.HasPrefix(strings.ToLower(b), strings.ToLower(a)+"-")
+}
+
+// Calls the F5XC whoami endpoint with client to verify the credentials of the chosen authentication method, and logs the
+// tenant, user and expiry of the credential. An error diagnostic tailored to the failure is returned if the credentials
+// cannot be verified, and a warning if the tenant of apiURL does not match the tenant of the client certificate cert,
+// which may be nil.
+func validateCredentials(ctx context.Context, client *http.Client, apiURL string, timeout time.Duration, auth *f5XCAuth, cert *x509.Certificate) diag.Diagnostics {
+	var diags diag.Diagnostics
+	endpoint, err := url.JoinPath(apiURL, "web", "custom", "namespaces", "system", "whoami")
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("url"),
+			"Invalid F5XC API URL",
+			"The provider cannot validate the F5XC API credentials as the API URL is invalid.\n\n"+
+				"Error: "+err.Error(),
+		)
+		return diags
+	}
+	clientCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	var whoAmI whoAmIJSON
+	err = doAPIRequest(clientCtx, client, http.MethodGet, endpoint, nil, &whoAmI)
+	switch {
+	case errors.Is(err, errUnauthorized):
+		diags.AddError(
+			"F5XC API credentials rejected",
+			"The F5XC API at "+apiURL+" rejected the "+auth.method+" from "+auth.sources+". Check that the credential "+
+				"has not expired or been revoked, and that it was issued by the tenant that owns the API URL.\n\n"+
+				"Error: "+err.Error(),
+		)
+		return diags
+	case errors.Is(err, errNotFound):
+		diags.AddAttributeError(
+			path.Root("url"),
+			"Unable to validate F5XC API credentials",
+			"The F5XC API identity endpoint was not found at "+endpoint+". Check that the url value is the API URL "+
+				"assigned to your tenant, including the /api path.",
+		)
+		return diags
+	case errors.Is(err, context.DeadlineExceeded):
+		diags.AddError(
+			"Unable to validate F5XC API credentials",
+			"The F5XC API at "+apiURL+" did not respond within the timeout of "+timeout.String()+". Check that the "+
+				"url value is correct and reachable from this host.\n\n"+
+				"Error: "+err.Error(),
+		)
+		return diags
+	case err != nil:
+		diags.AddError(
+			"Unable to validate F5XC API credentials",
+			"An unexpected error occurred when validating the "+auth.method+" from "+auth.sources+" with the F5XC API. "+
+				"A TLS handshake failure usually means the certificate was not accepted by the API.\n\n"+
+				"Error: "+err.Error(),
+		)
+		return diags
+	}
+
+	fields := map[string]any{
+		"tenant": whoAmI.Tenant,
+		"user":   whoAmI.Email,
+	}
+	if whoAmI.Email == "" {
+		fields["user"] = whoAmI.Name
+	}
+	if cert != nil {
+		fields["expiry"] = cert.NotAfter.Format(time.RFC3339)
+		if certTenant, apiTenant := certificateTenant(cert), urlTenant(apiURL); certTenant != "" && apiTenant != "" && !sameTenant(certTenant, apiTenant) {
+			diags.AddWarning(
+				"F5XC API tenant mismatch",
+				"The API URL "+apiURL+" belongs to tenant "+apiTenant+" but the certificate from "+auth.sources+
+					" was issued to tenant "+certTenant+". Requests may fail or act on the wrong tenant.",
+			)
+		}
+	}
+	tflog.Info(ctx, "Validated F5XC API credentials", fields)
+	return diags
+}
+
+// Returns a warning diagnostic if the client certificate cert, which may be nil, has expired or will expire within
+// window. A window of zero disables the warning.
+func checkCertificateExpiry(cert *x509.Certificate, auth *f5XCAuth, window time.Duration) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if cert == nil || window <= 0 {
+		return diags
+	}
+	remaining := time.Until(cert.NotAfter)
+	switch {
+	case remaining <= 0:
+		diags.AddWarning(
+			"F5XC API certificate has expired",
+			"The certificate of the "+auth.method+" from "+auth.sources+" expired at "+
+				cert.NotAfter.UTC().Format(time.RFC3339)+". Generate a new API certificate from the F5 Distributed "+
+				"Cloud console.",
+		)
+	case remaining <= window:
+		diags.AddWarning(
+			"F5XC API certificate expires soon",
+			"The certificate of the "+auth.method+" from "+auth.sources+" expires at "+
+				cert.NotAfter.UTC().Format(time.RFC3339)+", in "+remaining.Truncate(time.Hour).String()+". Generate a "+
+				"new API certificate from the F5 Distributed Cloud console before it expires.",
+		)
+	}
+	return diags
+}
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// Plaintext is a base64 encoded string; this is the default encoding.
+	plaintextEncodingBase64 = "base64"
+	// Plaintext is used as-is.
+	plaintextEncodingUTF8 = "utf8"
+	// Plaintext is a hex encoded string.
+	plaintextEncodingHex = "hex"
+)
+
+// The set of supported plaintext encodings, in the order they are documented.
+var plaintextEncodings = []string{plaintextEncodingBase64, plaintextEncodingUTF8, plaintextEncodingHex} //nolint:gochecknoglobals // Shared between validation and diagnostics.
+
+// errUnsupportedPlaintextEncoding is returned when plaintext is declared with an unknown encoding.
+var errUnsupportedPlaintextEncoding = errors.New("unsupported plaintext encoding")
+
+// errInvalidHexPlaintext is returned when hex encoded plaintext contains a character that is not a hex digit.
+var errInvalidHexPlaintext = errors.New("failed to decode hex plaintext: invalid hex character")
+
+// Decodes the plaintext value according to the named encoding; an empty encoding is treated as base64.
+func decodePlaintext(encoding, value string) ([]byte, error) {
+	switch encoding {
+	case "", plaintextEncodingBase64:
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode base64 plaintext: %w", err)
+		}
+		return decoded, nil
+	case plaintextEncodingUTF8:
+		return []byte(value), nil
+	case plaintextEncodingHex:
+		decoded, err := hex.DecodeString(value)
+		if err != nil {
+			// Don't leak the offending character of a sensitive value.
+			var invalid hex.InvalidByteError
+			if errors.As(err, &invalid) {
+				return nil, errInvalidHexPlaintext
+			}
+			return nil, fmt.Errorf("failed to decode hex plaintext: %w", err)
+		}
+		return decoded, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedPlaintextEncoding, encoding)
+	}
+}
+
+var _ validator.String = plaintextEncodingValidator{}
+
+// plaintextEncodingValidator validates a plaintext attribute against the encoding declared in the sibling
+// plaintext_encoding attribute so that invalid input is rejected during plan. When attached to the
+// plaintext_encoding attribute itself it verifies that the encoding is supported.
+type plaintextEncodingValidator struct{}
+
+// Implement the Description function for validator.Describer interface.
+func (v plaintextEncodingValidator) Description(_ context.Context) string {
+	return "value must be one of " + strings.Join(plaintextEncodings, ", ") + ", and plaintext must be valid for the encoding"
+}
+
+// Implement the MarkdownDescription function for validator.Describer interface.
+func (v plaintextEncodingValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// Implement the ValidateString function for validator.String interface.
+func (v plaintextEncodingValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	encodingPath := req.Path.ParentPath().AtName("plaintext_encoding")
+	if req.Path.Equal(encodingPath) {
+		if !slices.Contains(plaintextEncodings, req.ConfigValue.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				req.Path,
+				"Unsupported plaintext encoding",
+				fmt.Sprintf("The plaintext_encoding must be one of %s, got: %q.", strings.Join(plaintextEncodings, ", "), req.ConfigValue.ValueString()),
+			)
+		}
+		return
+	}
+
+	var encoding types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, encodingPath, &encoding)...)
+	if resp.Diagnostics.HasError() || encoding.IsUnknown() {
+		return
+	}
+	if _, err := decodePlaintext(encoding.ValueString(), req.ConfigValue.ValueString()); err != nil {
+		// Unsupported encodings are reported against plaintext_encoding.
+		if errors.Is(err, errUnsupportedPlaintextEncoding) {
+			return
+		}
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid plaintext",
+			"The plaintext value is not valid for the plaintext_encoding, unexpected error: "+err.Error(),
+		)
+	}
+}
+
+// Returns a plaintext encoding validator for plaintext and plaintext_encoding attributes.
+func validPlaintextEncoding() validator.String {
+	return plaintextEncodingValidator{}
+}
+
+// Requires replacement when the plaintext encoding is changed. Resources created before plaintext_encoding was added
+// have no recorded encoding; those plaintexts were base64 encoded, so adopting the default does not require a new
+// blindfold.
+func requiresReplaceIfPlaintextEncodingChanged(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) { //nolint:gocritic // RequiresReplaceIfFunc passes StringRequest by value.
+	if req.StateValue.IsNull() {
+		resp.RequiresReplace = req.PlanValue.ValueString() != plaintextEncodingBase64
+		return
+	}
+	resp.RequiresReplace = !req.StateValue.Equal(req.PlanValue)
+}
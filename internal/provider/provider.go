@@ -2,11 +2,17 @@ package provider
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -17,7 +23,14 @@ import (
 )
 
 // Ensure f5XCProvider satisfies various provider interfaces.
-var _ provider.Provider = &f5XCProvider{}
+var (
+	_ provider.Provider                       = &f5XCProvider{}
+	_ provider.ProviderWithEphemeralResources = &f5XCProvider{}
+	_ provider.ProviderWithConfigValidators   = &f5XCProvider{}
+)
+
+// errUnsupportedTransport is returned when inline x509 certificate and key content cannot be added to an HTTP client.
+var errUnsupportedTransport = errors.New("unsupported HTTP client transport")
 
 // f5XCProvider defines the provider implementation.
 type f5XCProvider struct {
@@ -27,15 +40,29 @@ type f5XCProvider struct {
 type f5XCConfig struct {
 	client  *http.Client
 	timeout time.Duration
+	url     string
+	cache   *blindfoldCache
+	// The authentication method used by client, and its x509 certificate which is nil for API tokens.
+	authMethod  string
+	certificate *x509.Certificate
 }
 
 type f5XCProviderModel struct {
-	PKCS12File types.String `tfsdk:"api_p12_file"`
-	Cert       types.String `tfsdk:"api_cert"`
-	Key        types.String `tfsdk:"api_key"`
-	Token      types.String `tfsdk:"api_token"`
-	Timeout    types.String `tfsdk:"timeout"`
-	URL        types.String `tfsdk:"url"`
+	PKCS12File     types.String  `tfsdk:"api_p12_file"`
+	PKCS12Content  types.String  `tfsdk:"api_p12_content"`
+	PKCS12Password types.String  `tfsdk:"api_p12_password"`
+	Cert           types.String  `tfsdk:"api_cert"`
+	Key            types.String  `tfsdk:"api_key"`
+	Token          types.String  `tfsdk:"api_token"`
+	Timeout        types.String  `tfsdk:"timeout"`
+	URL            types.String  `tfsdk:"url"`
+	CacheTTL       types.String  `tfsdk:"cache_ttl"`
+	Validate       types.Bool    `tfsdk:"validate_credentials"`
+	ExpiryWarning  types.String  `tfsdk:"credential_expiry_warning"`
+	MaxRetries     types.Int64   `tfsdk:"max_retries"`
+	RetryMin       types.String  `tfsdk:"retry_min_backoff"`
+	RetryMax       types.String  `tfsdk:"retry_max_backoff"`
+	RequestsPerSec types.Float64 `tfsdk:"requests_per_second"`
 }
 
 // New returns a function to create an F5XC Terraform provider matching the supplied version.
@@ -61,13 +88,24 @@ func (p *f5XCProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 				MarkdownDescription: "Path to a PKCS#12 file used to authenticate to F5 Distributed Cloud, can also be set using `VOLT_API_P12_FILE` environment variable.",
 				Optional:            true,
 			},
+			"api_p12_content": schema.StringAttribute{
+				MarkdownDescription: "The base64 encoded content of a PKCS#12 file used to authenticate to F5 Distributed Cloud, can also be set using `VES_P12_CONTENT` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"api_p12_password": schema.StringAttribute{
+				MarkdownDescription: "The passphrase to unlock the PKCS#12 file or content, can also be set using `VES_P12_PASSWORD` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
 			"api_cert": schema.StringAttribute{
-				MarkdownDescription: "Path to a PEM encoded x509 certificate file used to authenticate to F5 Distributed Cloud, can also be set using `VOLT_API_CERT` environment variable.",
+				MarkdownDescription: "Path to a PEM encoded x509 certificate file, or the PEM encoded certificate itself, used to authenticate to F5 Distributed Cloud, can also be set using `VOLT_API_CERT` environment variable.",
 				Optional:            true,
 			},
 			"api_key": schema.StringAttribute{
-				MarkdownDescription: "Path to a PEM encoded x509 key file used to authenticate to F5 Distributed Cloud, can also be set using `VOLT_API_KEY` environment variable.",
+				MarkdownDescription: "Path to a PEM encoded x509 key file, or the PEM encoded key itself, used to authenticate to F5 Distributed Cloud, can also be set using `VOLT_API_KEY` environment variable.",
 				Optional:            true,
+				Sensitive:           true,
 			},
 			"api_token": schema.StringAttribute{
 				MarkdownDescription: "An API token used to authenticate to F5 Distributed Cloud, can also be set using `VOLTERRA_TOKEN` environment variable.",
@@ -81,10 +119,53 @@ func (p *f5XCProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 				MarkdownDescription: "The F5 Distributed Cloud API URL assigned to your tenant, can also be set using `VOLT_API_URL` environment variable.",
 				Optional:            true,
 			},
+			"cache_ttl": schema.StringAttribute{
+				MarkdownDescription: "The duration for which the tenant public key and secret policy documents are shared between " +
+					"blindfold resources and data sources, defaults to `5m`. Set to `0s` to fetch the values for every " +
+					"resource and data source.",
+				Optional: true,
+			},
+			"credential_expiry_warning": schema.StringAttribute{
+				MarkdownDescription: "The duration before the F5 Distributed Cloud API certificate expires at which the " +
+					"provider warns that it should be renewed, defaults to `720h`. Set to `0s` to disable the warning.",
+				Optional: true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of times an idempotent request to F5 Distributed Cloud is " +
+					"retried after a transport error or a 429, 502, 503 or 504 response, defaults to `3`. Set to `0` to " +
+					"disable retries.",
+				Optional: true,
+			},
+			"retry_min_backoff": schema.StringAttribute{
+				MarkdownDescription: "The delay before the first retry of a failed request, which doubles for each " +
+					"subsequent retry, defaults to `1s`. A `Retry-After` header in the response takes precedence.",
+				Optional: true,
+			},
+			"retry_max_backoff": schema.StringAttribute{
+				MarkdownDescription: "The maximum delay between retries of a failed request, defaults to `30s`.",
+				Optional:            true,
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "The maximum rate of requests made to F5 Distributed Cloud, shared by all " +
+					"resources and data sources. Defaults to `0`, which does not limit the rate of requests.",
+				Optional: true,
+			},
+			"validate_credentials": schema.BoolAttribute{
+				MarkdownDescription: "Verify the credentials with the F5 Distributed Cloud API when the provider is " +
+					"configured, so that invalid or expired credentials fail before any resource is changed. Defaults to `false`.",
+				Optional: true,
+			},
 		},
 	}
 }
 
+// Implement the ConfigValidators function for ProviderWithConfigValidators interface.
+func (p *f5XCProvider) ConfigValidators(_ context.Context) []provider.ConfigValidator {
+	return []provider.ConfigValidator{
+		f5XCAuthConfigValidator{},
+	}
+}
+
 func (p *f5XCProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	tflog.Info(ctx, "Configuring F5XC API client")
 	var config f5XCProviderModel
@@ -102,6 +183,22 @@ func (p *f5XCProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		)
 	}
 
+	if config.PKCS12Content.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_p12_content"),
+			"Unknown F5XC API PKCS#12 Content",
+			"The provider cannot create the F5XC API client as there is an unknown configuration value for the F5XC API PKCS#12 content. Either target apply the source of the value first, set the value statically in the configuration, or use the VES_P12_CONTENT environment variable.",
+		)
+	}
+
+	if config.PKCS12Password.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_p12_password"),
+			"Unknown F5XC API PKCS#12 Passphrase",
+			"The provider cannot create the F5XC API client as there is an unknown configuration value for the F5XC API PKCS#12 passphrase. Either target apply the source of the value first, set the value statically in the configuration, or use the VES_P12_PASSWORD environment variable.",
+		)
+	}
+
 	if config.Cert.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("api_cert"),
@@ -142,31 +239,36 @@ func (p *f5XCProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		)
 	}
 
+	if config.CacheTTL.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("cache_ttl"),
+			"Unknown F5XC Cache TTL",
+			"The provider cannot create the F5XC API client as there is an unknown configuration value for the cache TTL. Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+	}
+
+	if config.ExpiryWarning.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("credential_expiry_warning"),
+			"Unknown F5XC Credential Expiry Warning",
+			"The provider cannot create the F5XC API client as there is an unknown configuration value for the credential expiry warning. Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+	}
+
+	if config.MaxRetries.IsUnknown() || config.RetryMin.IsUnknown() || config.RetryMax.IsUnknown() || config.RequestsPerSec.IsUnknown() {
+		resp.Diagnostics.AddError(
+			"Unknown F5XC Retry Policy",
+			"The provider cannot create the F5XC API client as there is an unknown configuration value for the retry policy. Either target apply the source of the value first, or set the max_retries, retry_min_backoff, retry_max_backoff, and requests_per_second values statically in the configuration.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	envP12Content := os.Getenv("VES_P12_CONTENT")
-	apiP12File := os.Getenv("VOLT_API_P12_FILE")
-	apiP12Passphrase := os.Getenv("VES_P12_PASSWORD")
-	apiCert := os.Getenv("VOLT_API_CERT")
-	apiKey := os.Getenv("VOLT_API_KEY")
-	apiToken := os.Getenv("VOLTERRA_TOKEN")
 	timeoutValue := os.Getenv("VOLT_API_TIMEOUT")
 	url := os.Getenv("VOLT_API_URL")
 
-	if !config.PKCS12File.IsNull() {
-		apiP12File = config.PKCS12File.ValueString()
-	}
-	if !config.Cert.IsNull() {
-		apiCert = config.Cert.ValueString()
-	}
-	if !config.Key.IsNull() {
-		apiKey = config.Key.ValueString()
-	}
-	if !config.Token.IsNull() {
-		apiToken = config.Token.ValueString()
-	}
 	if !config.Timeout.IsNull() {
 		timeoutValue = config.Timeout.ValueString()
 	}
@@ -189,6 +291,97 @@ func (p *f5XCProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		timeout = t
 	}
 
+	cacheTTL := 5 * time.Minute
+	if !config.CacheTTL.IsNull() {
+		t, err := time.ParseDuration(config.CacheTTL.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("cache_ttl"),
+				"Unable to parse cache TTL",
+				"An unexpected error occurred when parsing cache TTL. "+
+					"If the error is not clear, please contact the provider developers.\n\n"+
+					"Parse Error: "+err.Error(),
+			)
+			return
+		}
+		cacheTTL = t
+	}
+
+	expiryWarning := 30 * 24 * time.Hour
+	if !config.ExpiryWarning.IsNull() {
+		t, err := time.ParseDuration(config.ExpiryWarning.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("credential_expiry_warning"),
+				"Unable to parse credential expiry warning",
+				"An unexpected error occurred when parsing credential expiry warning. "+
+					"If the error is not clear, please contact the provider developers.\n\n"+
+					"Parse Error: "+err.Error(),
+			)
+			return
+		}
+		expiryWarning = t
+	}
+
+	maxRetries := int64(3)
+	if !config.MaxRetries.IsNull() {
+		maxRetries = config.MaxRetries.ValueInt64()
+	}
+	retryMin := time.Second
+	if !config.RetryMin.IsNull() {
+		t, err := time.ParseDuration(config.RetryMin.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_min_backoff"),
+				"Unable to parse minimum retry backoff",
+				"An unexpected error occurred when parsing minimum retry backoff. "+
+					"If the error is not clear, please contact the provider developers.\n\n"+
+					"Parse Error: "+err.Error(),
+			)
+			return
+		}
+		retryMin = t
+	}
+	retryMax := 30 * time.Second
+	if !config.RetryMax.IsNull() {
+		t, err := time.ParseDuration(config.RetryMax.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_max_backoff"),
+				"Unable to parse maximum retry backoff",
+				"An unexpected error occurred when parsing maximum retry backoff. "+
+					"If the error is not clear, please contact the provider developers.\n\n"+
+					"Parse Error: "+err.Error(),
+			)
+			return
+		}
+		retryMax = t
+	}
+	requestsPerSecond := config.RequestsPerSec.ValueFloat64()
+	switch {
+	case maxRetries < 0:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Invalid F5XC Retry Policy",
+			"The max_retries value must not be negative.",
+		)
+	case retryMin < 0 || retryMax < retryMin:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_max_backoff"),
+			"Invalid F5XC Retry Policy",
+			"The retry_min_backoff value must not be negative, and must not be greater than the retry_max_backoff value.",
+		)
+	case requestsPerSecond < 0:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("requests_per_second"),
+			"Invalid F5XC Retry Policy",
+			"The requests_per_second value must not be negative.",
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// url is required to be set
 	if url == "" {
 		resp.Diagnostics.AddAttributeError(
@@ -199,21 +392,23 @@ func (p *f5XCProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
-	options := []f5xc.Option{
-		f5xc.WithAPIEndpoint(url),
-	}
-	if apiToken != "" {
-		options = append(options, f5xc.WithAuthToken(apiToken))
-	}
-	if envP12Content != "" && apiP12Passphrase != "" {
-		options = append(options, f5xc.WithP12CertificateContent(envP12Content, apiP12Passphrase))
-	}
-	if apiP12File != "" && apiP12Passphrase != "" {
-		options = append(options, f5xc.WithP12CertificateFile(apiP12File, apiP12Passphrase))
+	auth := newF5XCAuth(&config)
+	authOptions, inlineCertKeyPair, diags := auth.options(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if apiCert != "" && apiKey != "" {
-		options = append(options, f5xc.WithCertKeyPair(apiCert, apiKey))
+	certificate, err := auth.certificate()
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to read F5XC API certificate",
+			"The provider could not parse the certificate of the "+auth.method+" from "+auth.sources+", so its expiry "+
+				"cannot be checked.\n\n"+
+				"Error: "+err.Error(),
+		)
 	}
+	resp.Diagnostics.Append(checkCertificateExpiry(certificate, auth, expiryWarning)...)
+	options := append([]f5xc.Option{f5xc.WithAPIEndpoint(url)}, authOptions...)
 	client, err := f5xc.NewClient(options...)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -224,22 +419,114 @@ func (p *f5XCProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		)
 		return
 	}
+	// Inline PEM values are added to the client's TLS configuration after it has been created.
+	if inlineCertKeyPair {
+		if err := withInlineCertKeyPair(client, auth.cert.value, auth.key.value); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Create F5XC API Client",
+				"An unexpected error occurred when adding the x509 certificate and key to the F5XC API client. "+
+					"If the error is not clear, please contact the provider developers.\n\n"+
+					"F5XC Client Error: "+err.Error(),
+			)
+			return
+		}
+	}
+	// Every API request made by resources and data sources is rate limited and retried by the client's transport.
+	client.Transport = newRetryTransport(client.Transport, int(maxRetries), retryMin, retryMax, requestsPerSecond)
+	if config.Validate.ValueBool() {
+		resp.Diagnostics.Append(validateCredentials(ctx, client, url, timeout, auth, certificate)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 	cfg := f5XCConfig{
 		client:  client,
 		timeout: timeout,
+		url:     url,
+		cache:   newBlindfoldCache(client, timeout, cacheTTL),
+
+		authMethod:  auth.method,
+		certificate: certificate,
 	}
 	resp.DataSourceData = &cfg
 	resp.ResourceData = &cfg
+	resp.EphemeralResourceData = &cfg
 }
 
 func (p *f5XCProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewBlindfoldResource,
 		NewBlindfoldFileResource,
+		NewBlindfoldMapResource,
+		NewBlindfoldFilesResource,
+		NewBlindfoldMultiResource,
+		NewBlindfoldSOPSResource,
+		NewBlindfoldTemplateResource,
+		NewSecretPolicyResource,
+		NewSecretPolicyRuleResource,
+	}
+}
+
+func (p *f5XCProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewBlindfoldEphemeralResource,
 	}
 }
 
-// The provider does not expose any datasources to Terraform.
 func (p *f5XCProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{
+		NewAPICredentialInfoDataSource,
+		NewPublicKeyDataSource,
+		NewSecretPolicyDocumentDataSource,
+	}
+}
+
+// Returns true if the value is PEM encoded content rather than the path to a PEM file.
+func isInlinePEM(value string) bool {
+	return strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN ")
+}
+
+// Returns the PEM encoded content of value, reading it from the file at value unless it is inline PEM content.
+func readPEM(value string) ([]byte, error) {
+	if isInlinePEM(value) {
+		return []byte(value), nil
+	}
+	data, err := os.ReadFile(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read PEM file: %w", err)
+	}
+	return data, nil
+}
+
+// Adds the x509 certificate and key pair, each of which may be inline PEM content or the path to a PEM file, to the
+// TLS configuration of the client's transport.
+func withInlineCertKeyPair(client *http.Client, cert, key string) error {
+	certPEM, err := readPEM(cert)
+	if err != nil {
+		return err
+	}
+	keyPEM, err := readPEM(key)
+	if err != nil {
+		return err
+	}
+	defer clear(keyPEM)
+	pair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return fmt.Errorf("failed to load x509 certificate and key pair: %w", err)
+	}
+	var transport *http.Transport
+	switch t := client.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert // The default transport is always an *http.Transport.
+	case *http.Transport:
+		transport = t.Clone()
+	default:
+		return fmt.Errorf("%w: %T", errUnsupportedTransport, client.Transport)
+	}
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	transport.TLSClientConfig.Certificates = append(transport.TLSClientConfig.Certificates, pair)
+	client.Transport = transport
 	return nil
 }
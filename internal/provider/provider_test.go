@@ -1,8 +1,14 @@
 package provider_test
 
 import (
+	"os"
+	"regexp"
+	"testing"
+
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/memes/terraform-provider-f5xc/internal/provider"
 )
 
@@ -22,3 +28,94 @@ provider "f5xc" {}
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
 	"f5xc": providerserver.NewProtocol6WithError(provider.New("test")()),
 }
+
+// testAccProtoV6ProviderFactoriesWithEcho adds the echo provider to the set of factories, which allows acceptance
+// tests to verify the results of ephemeral resources that are never written to plan or state.
+//
+//nolint:gochecknoglobals // Shared so all provider_test functions can create a provider per test case.
+var testAccProtoV6ProviderFactoriesWithEcho = map[string]func() (tfprotov6.ProviderServer, error){
+	"f5xc": providerserver.NewProtocol6WithError(provider.New("test")()),
+	"echo": echoprovider.NewProviderServer(),
+}
+
+func TestAccProviderInlineCertKeyPair(t *testing.T) {
+	t.Parallel()
+	cert, key := os.Getenv("VOLT_API_CERT"), os.Getenv("VOLT_API_KEY")
+	if cert == "" || key == "" {
+		t.Skip("VOLT_API_CERT and VOLT_API_KEY must be set to test inline PEM content")
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "f5xc" {
+	api_cert = file("` + cert + `")
+	api_key  = file("` + key + `")
+}
+
+data "f5xc_public_key" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.f5xc_public_key.test", "key_version"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProviderConflictingAuth(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "f5xc" {
+	api_token        = "token"
+	api_p12_file     = "missing.p12"
+	api_p12_password = "passphrase"
+}
+
+data "f5xc_public_key" "test" {}
+`,
+				ExpectError: regexp.MustCompile(`Conflicting F5XC API authentication`),
+			},
+			{
+				// The validator accepts a key from the environment as the other half of the pair.
+				SkipFunc: func() (bool, error) {
+					return os.Getenv("VOLT_API_KEY") != "", nil
+				},
+				Config: `
+provider "f5xc" {
+	api_cert = "missing.crt"
+}
+
+data "f5xc_public_key" "test" {}
+`,
+				ExpectError: regexp.MustCompile(`Missing F5XC API x509 key`),
+			},
+		},
+	})
+}
+
+func TestAccProviderValidateCredentials(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "f5xc" {
+	validate_credentials = true
+}
+
+data "f5xc_public_key" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.f5xc_public_key.test", "key_version"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &publicKeyDataSource{}
+	_ datasource.DataSourceWithConfigure = &publicKeyDataSource{}
+)
+
+type publicKeyDataSource struct {
+	cache *blindfoldCache
+}
+
+type publicKeyDataSourceModel struct {
+	KeyVersion           types.Int64  `tfsdk:"key_version"`
+	Tenant               types.String `tfsdk:"tenant"`
+	ModulusBase64        types.String `tfsdk:"modulus_base64"`
+	PublicExponentBase64 types.String `tfsdk:"public_exponent_base64"`
+	FetchedAt            types.String `tfsdk:"fetched_at"`
+}
+
+// publicKeyJSON mirrors the JSON representation of a tenant public key that is shared by the F5XC API and vesctl.
+type publicKeyJSON struct {
+	KeyVersion           int64  `json:"key_version"`
+	ModulusBase64        string `json:"modulus_base64"`
+	PublicExponentBase64 string `json:"public_exponent_base64"`
+	Tenant               string `json:"tenant"`
+}
+
+// NewPublicKeyDataSource creates a new public key Terraform data source and returns a pointer to it.
+func NewPublicKeyDataSource() datasource.DataSource {
+	return &publicKeyDataSource{}
+}
+
+// Implement the Metadata function for DataSource interface.
+func (d *publicKeyDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_public_key"
+}
+
+// Implement the Schema function for DataSource interface. The public key data source does not accept any inputs; all
+// attributes are populated from the current blindfold public key of the tenant.
+func (d *publicKeyDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the public key that F5 Distributed Cloud assigns to the tenant for blindfold " +
+			"operations.",
+		Attributes: map[string]schema.Attribute{
+			"key_version": schema.Int64Attribute{
+				Description: "The version of the tenant's public key.",
+				Computed:    true,
+			},
+			"tenant": schema.StringAttribute{
+				Description: "The tenant that owns the public key.",
+				Computed:    true,
+			},
+			"modulus_base64": schema.StringAttribute{
+				Description: "The base64 encoded modulus of the RSA public key.",
+				Computed:    true,
+			},
+			"public_exponent_base64": schema.StringAttribute{
+				Description: "The base64 encoded public exponent of the RSA public key.",
+				Computed:    true,
+			},
+			"fetched_at": schema.StringAttribute{
+				MarkdownDescription: "The RFC3339 timestamp of when the data source was read. The public key is shared " +
+					"with blindfold resources and may have been retrieved from F5 Distributed Cloud up to the provider " +
+					"`cache_ttl` earlier.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Implement the Configure function for DataSource interface.
+func (d *publicKeyDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*f5XCConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *f5XCConfig, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.cache = cfg.cache
+}
+
+// Implement the Read function for DataSource interface.
+func (d *publicKeyDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) { //nolint:gocritic // Provider interface passes ReadRequest by value.
+	tflog.Info(ctx, "Reading public key data source")
+
+	tflog.Debug(ctx, "Fetching Public Key")
+	pubKey, err := d.cache.publicKey(ctx)
+	switch {
+	case errors.Is(err, errPublicKeyNotFound):
+		resp.Diagnostics.AddError(
+			"Error retrieving PublicKey",
+			"PublicKey was not found for this account",
+		)
+		return
+	case err != nil:
+		resp.Diagnostics.AddError(
+			"Error retrieving PublicKey",
+			"Could not retrieve PublicKey, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	fetchedAt := time.Now().UTC()
+
+	// The public key is round-tripped through its JSON representation so that the attributes match the names used by
+	// the F5XC API.
+	var key publicKeyJSON
+	data, err := json.Marshal(pubKey)
+	if err == nil {
+		err = json.Unmarshal(data, &key)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error decoding PublicKey",
+			"Could not decode PublicKey, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	model := publicKeyDataSourceModel{
+		KeyVersion:           types.Int64Value(key.KeyVersion),
+		Tenant:               types.StringValue(key.Tenant),
+		ModulusBase64:        types.StringValue(key.ModulusBase64),
+		PublicExponentBase64: types.StringValue(key.PublicExponentBase64),
+		FetchedAt:            types.StringValue(fetchedAt.Format(time.RFC3339)),
+	}
+	diags := resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/memes/f5xc"
+)
+
+// The data source must read the public key from the provider cache that is shared with the blindfold resources.
+func TestPublicKeyDataSourceReadFromCache(t *testing.T) {
+	t.Parallel()
+	var pubKey f5xc.PublicKey
+	if err := json.Unmarshal([]byte(`{"key_version":3,"modulus_base64":"AQAB","public_exponent_base64":"AQAB","tenant":"test"}`), &pubKey); err != nil {
+		t.Fatalf("failed to decode public key: %v", err)
+	}
+	cache := newBlindfoldCache(nil, time.Second, time.Minute)
+	if _, err := cache.get(t.Context(), publicKeyCacheKey, func(_ context.Context) (any, error) {
+		return &pubKey, nil
+	}); err != nil {
+		t.Fatalf("failed to seed cache: %v", err)
+	}
+	d := &publicKeyDataSource{}
+	configureResp := &datasource.ConfigureResponse{}
+	d.Configure(t.Context(), datasource.ConfigureRequest{ProviderData: &f5XCConfig{cache: cache}}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected configure error: %v", configureResp.Diagnostics)
+	}
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(t.Context(), datasource.SchemaRequest{}, schemaResp)
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(t.Context()), nil),
+		},
+	}
+	d.Read(t.Context(), datasource.ReadRequest{}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read error: %v", resp.Diagnostics)
+	}
+	var model publicKeyDataSourceModel
+	if diags := resp.State.Get(t.Context(), &model); diags.HasError() {
+		t.Fatalf("failed to get state: %v", diags)
+	}
+	if model.KeyVersion.ValueInt64() != 3 || model.Tenant.ValueString() != "test" {
+		t.Errorf("expected cached public key, got version %d for tenant %q", model.KeyVersion.ValueInt64(), model.Tenant.ValueString())
+	}
+}
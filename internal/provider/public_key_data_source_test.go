@@ -0,0 +1,28 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccPublicKeyDataSource(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "f5xc_public_key" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.f5xc_public_key.test", "key_version"),
+					resource.TestCheckResourceAttrSet("data.f5xc_public_key.test", "tenant"),
+					resource.TestCheckResourceAttrSet("data.f5xc_public_key.test", "modulus_base64"),
+					resource.TestCheckResourceAttrSet("data.f5xc_public_key.test", "public_exponent_base64"),
+					resource.TestCheckResourceAttrSet("data.f5xc_public_key.test", "fetched_at"),
+				),
+			},
+		},
+	})
+}
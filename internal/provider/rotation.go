@@ -0,0 +1,297 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/memes/f5xc"
+)
+
+// The private state key used to record the public key and secret policy document used to blindfold a secret.
+const blindfoldPrivateStateKey = "blindfold"
+
+// privateStateGetter is implemented by the private state of resource requests.
+type privateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// privateStateSetter is implemented by the private state of resource responses.
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// blindfoldPrivateState records the version of the public key and a fingerprint of the secret policy document that were
+// used to blindfold a secret, so that rotation of either can be detected when the resource is refreshed.
+type blindfoldPrivateState struct {
+	KeyVersion        int64  `json:"key_version"`
+	PolicyFingerprint string `json:"policy_fingerprint"`
+	Rotated           bool   `json:"rotated,omitempty"`
+}
+
+// Returns a new blindfoldPrivateState for the public key and secret policy documents. The fingerprint of multiple policy
+// documents is calculated over their JSON representations in the order given.
+func newBlindfoldPrivateState(pubKey *f5xc.PublicKey, policyDocs ...*f5xc.SecretPolicyDocument) (*blindfoldPrivateState, error) {
+	// The public key is round-tripped through its JSON representation to extract the key version.
+	var key publicKeyJSON
+	data, err := json.Marshal(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode public key: %w", err)
+	}
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, fmt.Errorf("failed to decode public key: %w", err)
+	}
+	fingerprint := sha256.New()
+	for _, policyDoc := range policyDocs {
+		data, err = json.Marshal(policyDoc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode secret policy document: %w", err)
+		}
+		fingerprint.Write(data)
+	}
+	return &blindfoldPrivateState{
+		KeyVersion:        key.KeyVersion,
+		PolicyFingerprint: hex.EncodeToString(fingerprint.Sum(nil)),
+	}, nil
+}
+
+// Returns the blindfoldPrivateState stored in private state, or nil if it has not been recorded.
+func getBlindfoldPrivateState(ctx context.Context, private privateStateGetter) (*blindfoldPrivateState, diag.Diagnostics) {
+	data, diags := private.GetKey(ctx, blindfoldPrivateStateKey)
+	if diags.HasError() || len(data) == 0 {
+		return nil, diags
+	}
+	var state blindfoldPrivateState
+	if err := json.Unmarshal(data, &state); err != nil {
+		diags.AddError(
+			"Error decoding private state",
+			"Failed to decode blindfold private state, unexpected error: "+err.Error(),
+		)
+		return nil, diags
+	}
+	return &state, diags
+}
+
+// Stores the blindfoldPrivateState in private state.
+func (s *blindfoldPrivateState) set(ctx context.Context, private privateStateSetter) diag.Diagnostics {
+	data, err := json.Marshal(s)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError(
+			"Error encoding private state",
+			"Failed to encode blindfold private state, unexpected error: "+err.Error(),
+		)
+		return diags
+	}
+	return private.SetKey(ctx, blindfoldPrivateStateKey, data)
+}
+
+// Returns true if the public key version or secret policy document fingerprint differ from the recorded values.
+func (s *blindfoldPrivateState) differs(current *blindfoldPrivateState) bool {
+	return s.KeyVersion != current.KeyVersion || s.PolicyFingerprint != current.PolicyFingerprint
+}
+
+// Compares the tenant public key and secret policy documents currently in F5XC with the values that were recorded in
+// private state when the secret was blindfolded. If any have changed the private state is flagged so that ModifyPlan
+// will replace the resource, or a warning is added when replaceOnRotation is false.
+func checkBlindfoldRotation(ctx context.Context, cache *blindfoldCache, policies []policyDocumentModel, replaceOnRotation bool, private privateStateGetter, resp *resource.ReadResponse) {
+	recorded, diags := getBlindfoldPrivateState(ctx, private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Checking for Public Key and Secret Policy Document rotation")
+	pubKey, err := cache.publicKey(ctx)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to check for PublicKey rotation",
+			"Could not retrieve PublicKey, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	policyDocs := make([]*f5xc.SecretPolicyDocument, 0, len(policies))
+	names := make([]string, 0, len(policies))
+	for _, policy := range policies {
+		policyDoc, err := cache.secretPolicyDocument(ctx, policy.Name.ValueString(), policy.Namespace.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddWarning(
+				"Unable to check for SecretPolicyDocument changes",
+				"Could not retrieve SecretPolicyDocument, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		policyDocs = append(policyDocs, policyDoc)
+		names = append(names, policy.key())
+	}
+	current, err := newBlindfoldPrivateState(pubKey, policyDocs...)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to check for PublicKey rotation",
+			"Could not compare PublicKey and SecretPolicyDocument, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	switch {
+	case recorded == nil:
+		// Secrets blindfolded by earlier versions of the provider do not have a record of the public key or policy, so
+		// use the current values as the baseline for future comparisons.
+		tflog.Debug(ctx, "Recording Public Key version and Secret Policy Document fingerprint")
+		resp.Diagnostics.Append(current.set(ctx, resp.Private)...)
+	case !recorded.differs(current):
+		return
+	case replaceOnRotation:
+		tflog.Info(ctx, "Public Key or Secret Policy Document has changed, blindfold resource will be replaced")
+		recorded.Rotated = true
+		resp.Diagnostics.Append(recorded.set(ctx, resp.Private)...)
+	default:
+		resp.Diagnostics.AddWarning(
+			"Blindfolded secret is out of date",
+			fmt.Sprintf("The secret was blindfolded with PublicKey version %d, and the current version is %d, or the "+
+				"SecretPolicyDocument %s has changed since the secret was blindfolded. The sealed value will not be "+
+				"replaced automatically because replace_on_rotation is false.",
+				recorded.KeyVersion, current.KeyVersion, strings.Join(names, ", ")),
+		)
+	}
+}
+
+// Requires replacement of a blindfold resource if Read has flagged that the public key or secret policy document has
+// changed since the secret was blindfolded.
+func planBlindfoldRotation(ctx context.Context, req *resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when the resource is being created or destroyed.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	recorded, diags := getBlindfoldPrivateState(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || recorded == nil || !recorded.Rotated {
+		return
+	}
+	var replaceOnRotation types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("replace_on_rotation"), &replaceOnRotation)...)
+	if resp.Diagnostics.HasError() || !replaceOnRotation.ValueBool() {
+		return
+	}
+	tflog.Info(ctx, "Public Key or Secret Policy Document has changed, requiring replacement")
+	resp.Diagnostics.Append(setUnknownPlanAttribute(ctx, &resp.Plan, path.Root("sealed"))...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("sealed"))
+}
+
+// Returns diagnostics if the configured rotation_period is not a valid, positive duration.
+func validateRotationPeriod(ctx context.Context, config *tfsdk.Config) diag.Diagnostics {
+	var rotationPeriod types.String
+	diags := config.GetAttribute(ctx, path.Root("rotation_period"), &rotationPeriod)
+	if diags.HasError() || rotationPeriod.IsNull() || rotationPeriod.IsUnknown() {
+		return diags
+	}
+	period, err := time.ParseDuration(rotationPeriod.ValueString())
+	switch {
+	case err != nil:
+		diags.AddAttributeError(
+			path.Root("rotation_period"),
+			"Invalid rotation_period",
+			"The rotation_period attribute must be a valid duration, unexpected error: "+err.Error(),
+		)
+	case period <= 0:
+		diags.AddAttributeError(
+			path.Root("rotation_period"),
+			"Invalid rotation_period",
+			"The rotation_period attribute must be a positive duration, got: "+rotationPeriod.ValueString(),
+		)
+	}
+	return diags
+}
+
+// Records the current time as created_at for secrets that were blindfolded or imported before the creation time was
+// recorded, so that rotation_period can be enforced from the first refresh.
+func checkBlindfoldCreatedAt(ctx context.Context, createdAt types.String, resp *resource.ReadResponse) {
+	if !createdAt.IsNull() {
+		return
+	}
+	tflog.Debug(ctx, "Recording created_at for blindfold resource")
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("created_at"), time.Now().UTC().Format(time.RFC3339))...)
+}
+
+// Requires replacement of a blindfold resource if the secret was blindfolded longer ago than the configured
+// rotation_period.
+func planBlindfoldRotationPeriod(ctx context.Context, req *resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when the resource is being created or destroyed.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	var rotationPeriod, createdAt types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rotation_period"), &rotationPeriod)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("created_at"), &createdAt)...)
+	if resp.Diagnostics.HasError() || rotationPeriod.IsNull() || rotationPeriod.IsUnknown() || createdAt.IsNull() {
+		return
+	}
+	period, err := time.ParseDuration(rotationPeriod.ValueString())
+	if err != nil {
+		// Invalid durations are reported by ValidateConfig.
+		return
+	}
+	created, err := time.Parse(time.RFC3339, createdAt.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("created_at"),
+			"Unable to check rotation_period",
+			"The recorded created_at timestamp could not be parsed, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if time.Since(created) < period {
+		return
+	}
+	tflog.Info(ctx, "Blindfolded secret is older than rotation_period, requiring replacement", map[string]any{
+		"created_at":      createdAt.ValueString(),
+		"rotation_period": rotationPeriod.ValueString(),
+	})
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("created_at"), types.StringUnknown())...)
+	resp.Diagnostics.Append(setUnknownPlanAttribute(ctx, &resp.Plan, path.Root("sealed"))...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("created_at"))
+}
+
+// Requires replacement of a blindfold resource when its triggers are added, changed or removed. An imported secret has
+// no created_at timestamp, as it was not blindfolded by the provider, so triggers added to it are adopted without
+// blindfolding the secret again.
+func requiresReplaceIfTriggersChanged(ctx context.Context, req planmodifier.MapRequest, resp *mapplanmodifier.RequiresReplaceIfFuncResponse) { //nolint:gocritic // RequiresReplaceIfFunc passes MapRequest by value.
+	if !req.StateValue.IsNull() {
+		resp.RequiresReplace = true
+		return
+	}
+	var createdAt types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("created_at"), &createdAt)...)
+	resp.RequiresReplace = !createdAt.IsNull()
+}
+
+// Sets the planned value of the attribute at attrPath to unknown, regardless of the attribute type.
+func setUnknownPlanAttribute(ctx context.Context, plan *tfsdk.Plan, attrPath path.Path) diag.Diagnostics {
+	attrType, diags := plan.Schema.TypeAtPath(ctx, attrPath)
+	if diags.HasError() {
+		return diags
+	}
+	value, err := attrType.ValueFromTerraform(ctx, tftypes.NewValue(attrType.TerraformType(ctx), tftypes.UnknownValue))
+	if err != nil {
+		diags.AddAttributeError(
+			attrPath,
+			"Error planning unknown value",
+			"Failed to create an unknown value for the attribute, unexpected error: "+err.Error(),
+		)
+		return diags
+	}
+	return plan.SetAttribute(ctx, attrPath, value)
+}
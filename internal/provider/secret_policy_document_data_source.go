@@ -0,0 +1,264 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &secretPolicyDocumentDataSource{}
+	_ datasource.DataSourceWithConfigure = &secretPolicyDocumentDataSource{}
+)
+
+type secretPolicyDocumentDataSource struct {
+	cache *blindfoldCache
+}
+
+type clientNameMatcherModel struct {
+	ExactValues []types.String `tfsdk:"exact_values"`
+	RegexValues []types.String `tfsdk:"regex_values"`
+}
+
+type clientSelectorModel struct {
+	Expressions []types.String `tfsdk:"expressions"`
+}
+
+type secretPolicyDocumentRuleModel struct {
+	Action            types.String            `tfsdk:"action"`
+	ClientName        types.String            `tfsdk:"client_name"`
+	ClientNameMatcher *clientNameMatcherModel `tfsdk:"client_name_matcher"`
+	ClientSelector    *clientSelectorModel    `tfsdk:"client_selector"`
+}
+
+type secretPolicyDocumentDataSourceModel struct {
+	Name      types.String                    `tfsdk:"name"`
+	Namespace types.String                    `tfsdk:"namespace"`
+	Tenant    types.String                    `tfsdk:"tenant"`
+	PolicyID  types.String                    `tfsdk:"policy_id"`
+	Algo      types.String                    `tfsdk:"algo"`
+	Locked    types.Bool                      `tfsdk:"locked"`
+	Rules     []secretPolicyDocumentRuleModel `tfsdk:"rules"`
+}
+
+// secretPolicyDocumentJSON mirrors the JSON representation of a secret policy document that is shared by the F5XC API
+// and vesctl.
+type secretPolicyDocumentJSON struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Tenant    string `json:"tenant"`
+	Data      struct {
+		PolicyID   string `json:"policy_id"`
+		PolicyInfo struct {
+			Algo   string `json:"algo"`
+			Locked bool   `json:"locked"`
+			Rules  []struct {
+				Action            string `json:"action"`
+				ClientName        string `json:"client_name"`
+				ClientNameMatcher *struct {
+					ExactValues []string `json:"exact_values"`
+					RegexValues []string `json:"regex_values"`
+				} `json:"client_name_matcher"`
+				ClientSelector *struct {
+					Expressions []string `json:"expressions"`
+				} `json:"client_selector"`
+			} `json:"rules"`
+		} `json:"policy_info"`
+	} `json:"data"`
+}
+
+// NewSecretPolicyDocumentDataSource creates a new secret policy document Terraform data source and returns a pointer to
+// it.
+func NewSecretPolicyDocumentDataSource() datasource.DataSource {
+	return &secretPolicyDocumentDataSource{}
+}
+
+// Implement the Metadata function for DataSource interface.
+func (d *secretPolicyDocumentDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_secret_policy_document"
+}
+
+// Implement the Schema function for DataSource interface. Secret policy documents are looked up by name and namespace,
+// and the policy rules are exposed so that modules can verify the policy before using it to blindfold secrets.
+func (d *secretPolicyDocumentDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the F5 Distributed Cloud secret policy document that is used when " +
+			"blindfolding secrets.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "The name of the F5XC PolicyDocument to retrieve.",
+				Required:    true,
+			},
+			"namespace": schema.StringAttribute{
+				Description: "The namespace of the F5XC PolicyDocument to retrieve.",
+				Required:    true,
+			},
+			"tenant": schema.StringAttribute{
+				Description: "The tenant that owns the PolicyDocument.",
+				Computed:    true,
+			},
+			"policy_id": schema.StringAttribute{
+				Description: "The unique identifier of the secret policy.",
+				Computed:    true,
+			},
+			"algo": schema.StringAttribute{
+				Description: "The algorithm used to combine the results of the policy rules.",
+				Computed:    true,
+			},
+			"locked": schema.BoolAttribute{
+				Description: "True if the secret policy is locked and cannot be modified.",
+				Computed:    true,
+			},
+			"rules": schema.ListNestedAttribute{
+				Description: "The rules of the secret policy, in the order they are evaluated.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"action": schema.StringAttribute{
+							Description: "The action to take when the rule matches a client.",
+							Computed:    true,
+						},
+						"client_name": schema.StringAttribute{
+							Description: "The name of the client that is matched by the rule.",
+							Computed:    true,
+						},
+						"client_name_matcher": schema.SingleNestedAttribute{
+							Description: "The exact values and regular expressions used to match client names.",
+							Computed:    true,
+							Attributes: map[string]schema.Attribute{
+								"exact_values": schema.ListAttribute{
+									Description: "Client names that are matched exactly.",
+									Computed:    true,
+									ElementType: types.StringType,
+								},
+								"regex_values": schema.ListAttribute{
+									Description: "Regular expressions that are matched against client names.",
+									Computed:    true,
+									ElementType: types.StringType,
+								},
+							},
+						},
+						"client_selector": schema.SingleNestedAttribute{
+							Description: "The label selector used to match clients.",
+							Computed:    true,
+							Attributes: map[string]schema.Attribute{
+								"expressions": schema.ListAttribute{
+									Description: "Label selector expressions that are matched against client labels.",
+									Computed:    true,
+									ElementType: types.StringType,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Implement the Configure function for DataSource interface.
+func (d *secretPolicyDocumentDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*f5XCConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *f5XCConfig, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.cache = cfg.cache
+}
+
+// Implement the Read function for DataSource interface.
+func (d *secretPolicyDocumentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) { //nolint:gocritic // Provider interface passes ReadRequest by value.
+	tflog.Info(ctx, "Reading secret policy document data source")
+	var model secretPolicyDocumentDataSourceModel
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "policy_doc_name", model.Name.ValueString())
+	ctx = tflog.SetField(ctx, "policy_doc_namespace", model.Namespace.ValueString())
+
+	tflog.Debug(ctx, "Fetching Secret Policy Document")
+	policyDoc, err := d.cache.secretPolicyDocument(ctx, model.Name.ValueString(), model.Namespace.ValueString())
+	switch {
+	case errors.Is(err, errSecretPolicyDocumentNotFound):
+		resp.Diagnostics.AddError(
+			"Error retrieving SecretPolicyDocument",
+			"SecretPolicyDocument was not found; check the assigned values for name and namespace",
+		)
+		return
+	case err != nil:
+		resp.Diagnostics.AddError(
+			"Error retrieving SecretPolicyDocument",
+			"Could not retrieve SecretPolicyDocument, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// The policy document is round-tripped through its JSON representation so that the attributes match the names used
+	// by the F5XC API.
+	var doc secretPolicyDocumentJSON
+	data, err := json.Marshal(policyDoc)
+	if err == nil {
+		err = json.Unmarshal(data, &doc)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error decoding SecretPolicyDocument",
+			"Could not decode SecretPolicyDocument, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	model.Tenant = types.StringValue(doc.Tenant)
+	model.PolicyID = types.StringValue(doc.Data.PolicyID)
+	model.Algo = types.StringValue(doc.Data.PolicyInfo.Algo)
+	model.Locked = types.BoolValue(doc.Data.PolicyInfo.Locked)
+	model.Rules = make([]secretPolicyDocumentRuleModel, 0, len(doc.Data.PolicyInfo.Rules))
+	for _, rule := range doc.Data.PolicyInfo.Rules {
+		ruleModel := secretPolicyDocumentRuleModel{
+			Action:     types.StringValue(rule.Action),
+			ClientName: types.StringValue(rule.ClientName),
+		}
+		if rule.ClientNameMatcher != nil {
+			ruleModel.ClientNameMatcher = &clientNameMatcherModel{
+				ExactValues: stringValues(rule.ClientNameMatcher.ExactValues),
+				RegexValues: stringValues(rule.ClientNameMatcher.RegexValues),
+			}
+		}
+		if rule.ClientSelector != nil {
+			ruleModel.ClientSelector = &clientSelectorModel{
+				Expressions: stringValues(rule.ClientSelector.Expressions),
+			}
+		}
+		model.Rules = append(model.Rules, ruleModel)
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Returns a slice of Terraform string values from a slice of strings, or nil if the slice is empty.
+func stringValues(values []string) []types.String {
+	if len(values) == 0 {
+		return nil
+	}
+	result := make([]types.String, 0, len(values))
+	for _, value := range values {
+		result = append(result, types.StringValue(value))
+	}
+	return result
+}
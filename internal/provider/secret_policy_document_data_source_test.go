@@ -0,0 +1,31 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSecretPolicyDocumentDataSource(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "f5xc_secret_policy_document" "test" {
+	name = "ves-io-allow-volterra"
+	namespace = "shared"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.f5xc_secret_policy_document.test", "name", "ves-io-allow-volterra"),
+					resource.TestCheckResourceAttr("data.f5xc_secret_policy_document.test", "namespace", "shared"),
+					resource.TestCheckResourceAttrSet("data.f5xc_secret_policy_document.test", "policy_id"),
+					resource.TestCheckResourceAttrSet("data.f5xc_secret_policy_document.test", "locked"),
+					resource.TestCheckResourceAttrSet("data.f5xc_secret_policy_document.test", "rules.#"),
+				),
+			},
+		},
+	})
+}
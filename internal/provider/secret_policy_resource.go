@@ -0,0 +1,494 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                   = &secretPolicyResource{}
+	_ resource.ResourceWithConfigure      = &secretPolicyResource{}
+	_ resource.ResourceWithImportState    = &secretPolicyResource{}
+	_ resource.ResourceWithValidateConfig = &secretPolicyResource{}
+)
+
+// The kind of F5XC secret policy objects, as used in the API paths.
+const secretPolicyKind = "secret_policys"
+
+// The combining algorithms supported by F5XC secret policies.
+var secretPolicyAlgorithms = []string{"FIRST_MATCH", "DENY_OVERRIDES", "ALLOW_OVERRIDES"} //nolint:gochecknoglobals // Shared between schema description and validation.
+
+type secretPolicyResource struct {
+	client  *http.Client
+	timeout time.Duration
+	url     string
+}
+
+type secretPolicyResourceModel struct {
+	ID          types.String               `tfsdk:"id"`
+	Name        types.String               `tfsdk:"name"`
+	Namespace   types.String               `tfsdk:"namespace"`
+	Description types.String               `tfsdk:"description"`
+	Algo        types.String               `tfsdk:"algo"`
+	Rules       []secretPolicyRuleRefModel `tfsdk:"rules"`
+	Locked      types.Bool                 `tfsdk:"locked"`
+}
+
+// secretPolicyRuleRefModel is a reference to a secret policy rule that is evaluated by a secret policy.
+type secretPolicyRuleRefModel struct {
+	Name      types.String `tfsdk:"name"`
+	Namespace types.String `tfsdk:"namespace"`
+}
+
+// secretPolicySpecJSON mirrors the specification of an F5XC secret policy object.
+type secretPolicySpecJSON struct {
+	Algo  string          `json:"algo,omitempty"`
+	Rules []objectRefJSON `json:"rules,omitempty"`
+}
+
+// secretPolicyJSON mirrors the F5XC secret policy object as sent to, and received from, the F5XC API.
+type secretPolicyJSON struct {
+	Metadata objectMetadataJSON   `json:"metadata"`
+	Spec     secretPolicySpecJSON `json:"spec"`
+}
+
+// NewSecretPolicyResource creates a new secret policy Terraform resource and returns a pointer to it.
+func NewSecretPolicyResource() resource.Resource {
+	return &secretPolicyResource{}
+}
+
+// Implement the Metadata function for Resource interface.
+func (r *secretPolicyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_secret_policy"
+}
+
+// Implement the Schema function for Resource interface. Secret policies are identified by name and namespace, and
+// reference an ordered list of secret policy rules.
+func (r *secretPolicyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an F5 Distributed Cloud secret policy, which determines the clients that are " +
+			"permitted to decrypt blindfolded secrets.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The computed resource identifier for the secret policy, in the form namespace/name.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the secret policy.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"namespace": schema.StringAttribute{
+				Description: "The namespace of the secret policy.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "A human readable description of the secret policy.",
+				Optional:    true,
+			},
+			"algo": schema.StringAttribute{
+				MarkdownDescription: "The algorithm used to combine the results of the policy rules; one of `" +
+					strings.Join(secretPolicyAlgorithms, "`, `") + "`. Defaults to `FIRST_MATCH`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("FIRST_MATCH"),
+			},
+			"rules": schema.ListNestedAttribute{
+				MarkdownDescription: "The ordered list of secret policy rules, such as `f5xc_secret_policy_rule` " +
+					"resources, that are evaluated by the policy.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The name of the secret policy rule.",
+							Required:    true,
+						},
+						"namespace": schema.StringAttribute{
+							Description: "The namespace of the secret policy rule.",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"locked": schema.BoolAttribute{
+				Description: "Lock the secret policy to prevent further changes to the policy. Defaults to false. The lock " +
+					"state is not reported by the F5 Distributed Cloud API, so changes made outside of Terraform are not " +
+					"detected and the lock state must be included when importing a secret policy.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+// Implement the Configure function for Resource interface.
+func (r *secretPolicyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*f5XCConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *f5XCConfig, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = cfg.client
+	r.timeout = cfg.timeout
+	r.url = cfg.url
+}
+
+// Implement the ValidateConfig function for ResourceWithValidateConfig interface.
+func (r *secretPolicyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) { //nolint:gocritic // Provider interface passes ValidateConfigRequest by value.
+	var algo types.String
+	diags := req.Config.GetAttribute(ctx, path.Root("algo"), &algo)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if algo.IsNull() || algo.IsUnknown() {
+		return
+	}
+	if !slices.Contains(secretPolicyAlgorithms, algo.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("algo"),
+			"Invalid secret policy algorithm",
+			fmt.Sprintf("The algo attribute must be one of %s, got: %q", strings.Join(secretPolicyAlgorithms, ", "), algo.ValueString()),
+		)
+	}
+}
+
+// Implement the Create function for Resource interface.
+func (r *secretPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) { //nolint:gocritic // Provider interface passes CreateRequest by value.
+	tflog.Info(ctx, "Creating secret policy resource")
+	var model secretPolicyResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "secret_policy_name", model.Name.ValueString())
+	ctx = tflog.SetField(ctx, "secret_policy_namespace", model.Namespace.ValueString())
+
+	tflog.Debug(ctx, "Creating Secret Policy")
+	endpoint, err := secretManagementURL(r.url, model.Namespace.ValueString(), secretPolicyKind)
+	if err == nil {
+		clientCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+		err = doAPIRequest(clientCtx, r.client, http.MethodPost, endpoint, model.toJSON(), nil)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating SecretPolicy",
+			"Could not create SecretPolicy, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	model.ID = types.StringValue(model.Namespace.ValueString() + "/" + model.Name.ValueString())
+
+	if model.Locked.ValueBool() {
+		if err := r.setLocked(ctx, &model); err != nil {
+			// The policy exists but is not locked; record it in state so that Terraform manages the existing policy,
+			// rather than trying to create it again.
+			model.Locked = types.BoolValue(false)
+			resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+			resp.Diagnostics.AddError(
+				"Error locking SecretPolicy",
+				"Could not lock SecretPolicy, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Implement the Read function for Resource interface. The secret policy will be removed from state if it no longer
+// exists in F5XC.
+func (r *secretPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) { //nolint:gocritic // Provider interface passes ReadRequest by value.
+	tflog.Info(ctx, "Reading secret policy resource")
+	var model secretPolicyResourceModel
+	diags := req.State.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "secret_policy_name", model.Name.ValueString())
+	ctx = tflog.SetField(ctx, "secret_policy_namespace", model.Namespace.ValueString())
+
+	tflog.Debug(ctx, "Fetching Secret Policy")
+	var policy secretPolicyJSON
+	endpoint, err := secretManagementURL(r.url, model.Namespace.ValueString(), secretPolicyKind, model.Name.ValueString())
+	if err == nil {
+		clientCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+		err = doAPIRequest(clientCtx, r.client, http.MethodGet, endpoint, nil, &policy)
+	}
+	switch {
+	case errors.Is(err, errNotFound):
+		tflog.Warn(ctx, "Secret Policy was not found, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	case err != nil:
+		resp.Diagnostics.AddError(
+			"Error retrieving SecretPolicy",
+			"Could not retrieve SecretPolicy, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	model.fromJSON(&policy)
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Implement the Update function for Resource interface. The secret policy will be unlocked before changes are made if
+// necessary, and locked again afterwards if requested.
+func (r *secretPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) { //nolint:gocritic // Provider interface passes UpdateRequest by value.
+	tflog.Info(ctx, "Updating secret policy resource")
+	var model, state secretPolicyResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "secret_policy_name", model.Name.ValueString())
+	ctx = tflog.SetField(ctx, "secret_policy_namespace", model.Namespace.ValueString())
+
+	wasLocked := state.Locked.ValueBool()
+	if wasLocked {
+		state.Locked = types.BoolValue(false)
+		if err := r.setLocked(ctx, &state); err != nil {
+			// Nothing has changed in F5XC, so keep the prior state.
+			state.Locked = types.BoolValue(true)
+			resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+			resp.Diagnostics.AddError(
+				"Error unlocking SecretPolicy",
+				"Could not unlock SecretPolicy, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	tflog.Debug(ctx, "Replacing Secret Policy")
+	endpoint, err := secretManagementURL(r.url, model.Namespace.ValueString(), secretPolicyKind, model.Name.ValueString())
+	if err == nil {
+		clientCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+		err = doAPIRequest(clientCtx, r.client, http.MethodPut, endpoint, model.toJSON(), nil)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating SecretPolicy",
+			"Could not update SecretPolicy, unexpected error: "+err.Error(),
+		)
+		if wasLocked {
+			r.relock(ctx, &state, &resp.Diagnostics)
+		}
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+		return
+	}
+	model.ID = state.ID
+
+	if model.Locked.ValueBool() {
+		if err := r.setLocked(ctx, &model); err != nil {
+			// The policy has been updated but is still unlocked; record that in state so that the next apply locks
+			// it again.
+			model.Locked = types.BoolValue(false)
+			resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+			resp.Diagnostics.AddError(
+				"Error locking SecretPolicy",
+				"Could not lock SecretPolicy, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Makes a best-effort attempt to lock a secret policy again after an update that unlocked it has failed. The locked
+// attribute of the model is set to the resulting lock status, so that state matches the policy in F5XC.
+func (r *secretPolicyResource) relock(ctx context.Context, model *secretPolicyResourceModel, diags *diag.Diagnostics) {
+	model.Locked = types.BoolValue(true)
+	if err := r.setLocked(ctx, model); err != nil {
+		model.Locked = types.BoolValue(false)
+		diags.AddWarning(
+			"Unable to lock SecretPolicy again",
+			"The SecretPolicy was unlocked to apply changes, and could not be locked again after the update failed. "+
+				"It will be locked by the next apply, unexpected error: "+err.Error(),
+		)
+	}
+}
+
+// Implement the Delete function for Resource interface. A locked secret policy will be unlocked before it is deleted.
+func (r *secretPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) { //nolint:gocritic // Provider interface passes DeleteRequest by value.
+	tflog.Info(ctx, "Deleting secret policy resource")
+	var model secretPolicyResourceModel
+	diags := req.State.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "secret_policy_name", model.Name.ValueString())
+	ctx = tflog.SetField(ctx, "secret_policy_namespace", model.Namespace.ValueString())
+
+	if model.Locked.ValueBool() {
+		model.Locked = types.BoolValue(false)
+		if err := r.setLocked(ctx, &model); err != nil && !errors.Is(err, errNotFound) {
+			resp.Diagnostics.AddError(
+				"Error unlocking SecretPolicy",
+				"Could not unlock SecretPolicy, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	tflog.Debug(ctx, "Deleting Secret Policy")
+	endpoint, err := secretManagementURL(r.url, model.Namespace.ValueString(), secretPolicyKind, model.Name.ValueString())
+	if err == nil {
+		clientCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+		err = doAPIRequest(clientCtx, r.client, http.MethodDelete, endpoint, nil, nil)
+	}
+	if err != nil && !errors.Is(err, errNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting SecretPolicy",
+			"Could not delete SecretPolicy, unexpected error: "+err.Error(),
+		)
+	}
+}
+
+// Implement the ImportState function for ResourceWithImportState interface. Secret policies are imported using an
+// identifier of the form namespace/name:locked or namespace/name:unlocked; the lock state is part of the identifier
+// because the F5XC API does not report it.
+func (r *secretPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	namespace, name, locked, ok := parseSecretPolicyImportID(req.ID)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Invalid import identifier",
+			"Expected an import identifier of the form namespace/name:locked or namespace/name:unlocked, got: "+req.ID,
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), namespace+"/"+name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("namespace"), namespace)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("locked"), locked)...)
+}
+
+// Returns the namespace, name and lock state of a secret policy import identifier of the form namespace/name:locked or
+// namespace/name:unlocked. The final return value is false if the identifier is not valid.
+func parseSecretPolicyImportID(id string) (string, string, bool, bool) {
+	ref, state, _ := strings.Cut(id, ":")
+	namespace, name, ok := strings.Cut(ref, "/")
+	if !ok || namespace == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", false, false
+	}
+	switch state {
+	case "locked":
+		return namespace, name, true, true
+	case "unlocked":
+		return namespace, name, false, true
+	}
+	return "", "", false, false
+}
+
+// Locks or unlocks the secret policy to match the locked attribute of the model.
+func (r *secretPolicyResource) setLocked(ctx context.Context, model *secretPolicyResourceModel) error {
+	action := "unlock"
+	if model.Locked.ValueBool() {
+		action = "lock"
+	}
+	tflog.Debug(ctx, "Changing Secret Policy lock", map[string]any{"action": action})
+	endpoint, err := secretManagementURL(r.url, model.Namespace.ValueString(), secretPolicyKind, model.Name.ValueString(), action)
+	if err != nil {
+		return err
+	}
+	clientCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return doAPIRequest(clientCtx, r.client, http.MethodPost, endpoint, objectRefJSON{
+		Name:      model.Name.ValueString(),
+		Namespace: model.Namespace.ValueString(),
+	}, nil)
+}
+
+// Returns the F5XC API representation of the secret policy model.
+func (m *secretPolicyResourceModel) toJSON() *secretPolicyJSON {
+	policy := &secretPolicyJSON{
+		Metadata: objectMetadataJSON{
+			Name:        m.Name.ValueString(),
+			Namespace:   m.Namespace.ValueString(),
+			Description: m.Description.ValueString(),
+		},
+		Spec: secretPolicySpecJSON{
+			Algo: m.Algo.ValueString(),
+		},
+	}
+	for _, rule := range m.Rules {
+		policy.Spec.Rules = append(policy.Spec.Rules, objectRefJSON{
+			Name:      rule.Name.ValueString(),
+			Namespace: rule.Namespace.ValueString(),
+		})
+	}
+	return policy
+}
+
+// Updates the secret policy model from the F5XC API representation; the locked attribute is not reported by the API and
+// is left unchanged.
+func (m *secretPolicyResourceModel) fromJSON(policy *secretPolicyJSON) {
+	m.Name = types.StringValue(policy.Metadata.Name)
+	m.Namespace = types.StringValue(policy.Metadata.Namespace)
+	m.ID = types.StringValue(policy.Metadata.Namespace + "/" + policy.Metadata.Name)
+	m.Description = optionalStringValue(policy.Metadata.Description)
+	m.Algo = types.StringValue("FIRST_MATCH")
+	if policy.Spec.Algo != "" {
+		m.Algo = types.StringValue(policy.Spec.Algo)
+	}
+	m.Rules = nil
+	for _, rule := range policy.Spec.Rules {
+		m.Rules = append(m.Rules, secretPolicyRuleRefModel{
+			Name:      types.StringValue(rule.Name),
+			Namespace: types.StringValue(rule.Namespace),
+		})
+	}
+}
+
+// Returns a Terraform string value for the string, or a null value if the string is empty.
+func optionalStringValue(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestParseSecretPolicyImportID(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		id                string
+		expectedNamespace string
+		expectedName      string
+		expectedLocked    bool
+		expectedOK        bool
+	}{
+		{id: "shared/policy:locked", expectedNamespace: "shared", expectedName: "policy", expectedLocked: true, expectedOK: true},
+		{id: "shared/policy:unlocked", expectedNamespace: "shared", expectedName: "policy", expectedOK: true},
+		{id: "shared/policy"},
+		{id: "shared/policy:true"},
+		{id: "shared:locked"},
+		{id: "/policy:locked"},
+		{id: "shared/:locked"},
+		{id: "shared/policy/extra:locked"},
+	}
+	for _, test := range tests {
+		t.Run(test.id, func(t *testing.T) {
+			t.Parallel()
+			namespace, name, locked, ok := parseSecretPolicyImportID(test.id)
+			if ok != test.expectedOK {
+				t.Fatalf("expected ok to be %t, got %t", test.expectedOK, ok)
+			}
+			if namespace != test.expectedNamespace || name != test.expectedName || locked != test.expectedLocked {
+				t.Errorf("expected %s/%s locked %t, got %s/%s locked %t", test.expectedNamespace, test.expectedName, test.expectedLocked, namespace, name, locked)
+			}
+		})
+	}
+}
+
+// Failed lock changes must leave state matching the lock status of the policy in F5XC.
+func TestSecretPolicyResourceLockFailures(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		create         bool
+		stateLocked    bool
+		planLocked     bool
+		failPaths      []string
+		expectedLocked bool
+		expectedAlgo   string
+	}{
+		{
+			name:         "create lock fails",
+			create:       true,
+			planLocked:   true,
+			failPaths:    []string{"/lock"},
+			expectedAlgo: "DENY_OVERRIDES",
+		},
+		{
+			name:           "update unlock fails",
+			stateLocked:    true,
+			planLocked:     true,
+			failPaths:      []string{"/unlock"},
+			expectedLocked: true,
+			expectedAlgo:   "FIRST_MATCH",
+		},
+		{
+			name:           "update put fails",
+			stateLocked:    true,
+			planLocked:     true,
+			failPaths:      []string{"/test"},
+			expectedLocked: true,
+			expectedAlgo:   "FIRST_MATCH",
+		},
+		{
+			name:         "update put and relock fail",
+			stateLocked:  true,
+			planLocked:   true,
+			failPaths:    []string{"/test", "/lock"},
+			expectedAlgo: "FIRST_MATCH",
+		},
+		{
+			name:         "update lock fails",
+			planLocked:   true,
+			failPaths:    []string{"/lock"},
+			expectedAlgo: "DENY_OVERRIDES",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for _, suffix := range test.failPaths {
+					if strings.HasSuffix(r.URL.Path, suffix) && (suffix != "/test" || r.Method == http.MethodPut) {
+						w.WriteHeader(http.StatusInternalServerError)
+						return
+					}
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			t.Cleanup(server.Close)
+			ctx := context.Background()
+			r := &secretPolicyResource{client: server.Client(), timeout: 5 * time.Second, url: server.URL}
+			schemaResp := &resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+			newState := func(algo string, locked bool) tfsdk.State {
+				state := tfsdk.State{
+					Schema: schemaResp.Schema,
+					Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+				}
+				if diags := state.Set(ctx, &secretPolicyResourceModel{
+					ID:          types.StringValue("shared/test"),
+					Name:        types.StringValue("test"),
+					Namespace:   types.StringValue("shared"),
+					Description: types.StringNull(),
+					Algo:        types.StringValue(algo),
+					Locked:      types.BoolValue(locked),
+				}); diags.HasError() {
+					t.Fatalf("failed to set state: %v", diags)
+				}
+				return state
+			}
+			planned := newState("DENY_OVERRIDES", test.planLocked)
+			plan := tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}
+			var result tfsdk.State
+			if test.create {
+				resp := &resource.CreateResponse{State: tfsdk.State{Schema: planned.Schema, Raw: tftypes.NewValue(planned.Raw.Type(), nil)}}
+				r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected error diagnostics")
+				}
+				result = resp.State
+			} else {
+				// The framework initialises the new state from the plan.
+				resp := &resource.UpdateResponse{State: tfsdk.State{Schema: planned.Schema, Raw: planned.Raw}}
+				r.Update(ctx, resource.UpdateRequest{Plan: plan, State: newState("FIRST_MATCH", test.stateLocked)}, resp)
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected error diagnostics")
+				}
+				result = resp.State
+			}
+			var model secretPolicyResourceModel
+			if diags := result.Get(ctx, &model); diags.HasError() {
+				t.Fatalf("failed to get state: %v", diags)
+			}
+			if model.Locked.ValueBool() != test.expectedLocked {
+				t.Errorf("expected locked to be %t, got %t", test.expectedLocked, model.Locked.ValueBool())
+			}
+			if model.Algo.ValueString() != test.expectedAlgo {
+				t.Errorf("expected algo %q, got %q", test.expectedAlgo, model.Algo.ValueString())
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSecretPolicyResource(t *testing.T) {
+	t.Parallel()
+	name := acctest.RandomWithPrefix("tf-acc")
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "f5xc_secret_policy_rule" "test" {
+	name = "` + name + `"
+	namespace = "shared"
+	action = "ALLOW"
+	client_name = "ves-io-system"
+}
+
+resource "f5xc_secret_policy" "test" {
+	name = "` + name + `"
+	namespace = "shared"
+	rules = [
+		{
+			name = f5xc_secret_policy_rule.test.name
+			namespace = f5xc_secret_policy_rule.test.namespace
+		},
+	]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("f5xc_secret_policy.test", "id", "shared/"+name),
+					resource.TestCheckResourceAttr("f5xc_secret_policy.test", "algo", "FIRST_MATCH"),
+					resource.TestCheckResourceAttr("f5xc_secret_policy.test", "rules.#", "1"),
+					resource.TestCheckResourceAttr("f5xc_secret_policy.test", "locked", "false"),
+				),
+			},
+			{
+				ResourceName:      "f5xc_secret_policy.test",
+				ImportState:       true,
+				ImportStateId:     "shared/" + name + ":unlocked",
+				ImportStateVerify: true,
+			},
+			{
+				Config: providerConfig + `
+resource "f5xc_secret_policy_rule" "test" {
+	name = "` + name + `"
+	namespace = "shared"
+	action = "ALLOW"
+	client_name = "ves-io-system"
+}
+
+resource "f5xc_secret_policy" "test" {
+	name = "` + name + `"
+	namespace = "shared"
+	rules = [
+		{
+			name = f5xc_secret_policy_rule.test.name
+			namespace = f5xc_secret_policy_rule.test.namespace
+		},
+	]
+	locked = true
+}
+
+resource "f5xc_blindfold" "test" {
+	plaintext = "VGhpcyBpcyBhIHRlc3Q="
+	policy_document = {
+		name = f5xc_secret_policy.test.name
+		namespace = f5xc_secret_policy.test.namespace
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("f5xc_secret_policy.test", "locked", "true"),
+					resource.TestCheckResourceAttrSet("f5xc_blindfold.test", "sealed"),
+				),
+			},
+			{
+				ResourceName:      "f5xc_secret_policy.test",
+				ImportState:       true,
+				ImportStateId:     "shared/" + name + ":locked",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,420 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                   = &secretPolicyRuleResource{}
+	_ resource.ResourceWithConfigure      = &secretPolicyRuleResource{}
+	_ resource.ResourceWithImportState    = &secretPolicyRuleResource{}
+	_ resource.ResourceWithValidateConfig = &secretPolicyRuleResource{}
+)
+
+// The kind of F5XC secret policy rule objects, as used in the API paths.
+const secretPolicyRuleKind = "secret_policy_rules"
+
+// The actions supported by F5XC secret policy rules.
+var secretPolicyRuleActions = []string{"ALLOW", "DENY"} //nolint:gochecknoglobals // Shared between schema description and validation.
+
+type secretPolicyRuleResource struct {
+	client  *http.Client
+	timeout time.Duration
+	url     string
+}
+
+type secretPolicyRuleResourceModel struct {
+	ID                types.String            `tfsdk:"id"`
+	Name              types.String            `tfsdk:"name"`
+	Namespace         types.String            `tfsdk:"namespace"`
+	Description       types.String            `tfsdk:"description"`
+	Action            types.String            `tfsdk:"action"`
+	ClientName        types.String            `tfsdk:"client_name"`
+	ClientNameMatcher *clientNameMatcherModel `tfsdk:"client_name_matcher"`
+	ClientSelector    *clientSelectorModel    `tfsdk:"client_selector"`
+}
+
+// clientNameMatcherJSON mirrors the client name matcher of an F5XC secret policy rule.
+type clientNameMatcherJSON struct {
+	ExactValues []string `json:"exact_values,omitempty"`
+	RegexValues []string `json:"regex_values,omitempty"`
+}
+
+// clientSelectorJSON mirrors the label selector of an F5XC secret policy rule.
+type clientSelectorJSON struct {
+	Expressions []string `json:"expressions,omitempty"`
+}
+
+// secretPolicyRuleSpecJSON mirrors the specification of an F5XC secret policy rule object.
+type secretPolicyRuleSpecJSON struct {
+	Action            string                 `json:"action"`
+	ClientName        string                 `json:"client_name,omitempty"`
+	ClientNameMatcher *clientNameMatcherJSON `json:"client_name_matcher,omitempty"`
+	ClientSelector    *clientSelectorJSON    `json:"client_selector,omitempty"`
+}
+
+// secretPolicyRuleJSON mirrors the F5XC secret policy rule object as sent to, and received from, the F5XC API.
+type secretPolicyRuleJSON struct {
+	Metadata objectMetadataJSON       `json:"metadata"`
+	Spec     secretPolicyRuleSpecJSON `json:"spec"`
+}
+
+// NewSecretPolicyRuleResource creates a new secret policy rule Terraform resource and returns a pointer to it.
+func NewSecretPolicyRuleResource() resource.Resource {
+	return &secretPolicyRuleResource{}
+}
+
+// Implement the Metadata function for Resource interface.
+func (r *secretPolicyRuleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_secret_policy_rule"
+}
+
+// Implement the Schema function for Resource interface. Secret policy rules match clients by exact name, by a set of
+// exact names and regular expressions, or by a label selector.
+func (r *secretPolicyRuleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an F5 Distributed Cloud secret policy rule, which allows or denies access to " +
+			"blindfolded secrets for matching clients.\n\n" +
+			"Exactly one of `client_name`, `client_name_matcher`, or `client_selector` must be provided.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The computed resource identifier for the secret policy rule, in the form namespace/name.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the secret policy rule.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"namespace": schema.StringAttribute{
+				Description: "The namespace of the secret policy rule.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "A human readable description of the secret policy rule.",
+				Optional:    true,
+			},
+			"action": schema.StringAttribute{
+				MarkdownDescription: "The action to take when the rule matches a client; one of `" +
+					strings.Join(secretPolicyRuleActions, "`, `") + "`.",
+				Required: true,
+			},
+			"client_name": schema.StringAttribute{
+				Description: "The exact name of the client that is matched by the rule.",
+				Optional:    true,
+			},
+			"client_name_matcher": schema.SingleNestedAttribute{
+				Description: "A set of exact values and regular expressions used to match client names.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"exact_values": schema.ListAttribute{
+						Description: "Client names that are matched exactly.",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"regex_values": schema.ListAttribute{
+						Description: "Regular expressions that are matched against client names.",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+				},
+			},
+			"client_selector": schema.SingleNestedAttribute{
+				Description: "A label selector used to match clients.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"expressions": schema.ListAttribute{
+						Description: "Label selector expressions that are matched against client labels.",
+						Required:    true,
+						ElementType: types.StringType,
+					},
+				},
+			},
+		},
+	}
+}
+
+// Implement the Configure function for Resource interface.
+func (r *secretPolicyRuleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*f5XCConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *f5XCConfig, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = cfg.client
+	r.timeout = cfg.timeout
+	r.url = cfg.url
+}
+
+// Implement the ValidateConfig function for ResourceWithValidateConfig interface. The action must be supported by F5XC,
+// and exactly one client matching attribute must be provided.
+func (r *secretPolicyRuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) { //nolint:gocritic // Provider interface passes ValidateConfigRequest by value.
+	var action, clientName types.String
+	var clientNameMatcher, clientSelector types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("action"), &action)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("client_name"), &clientName)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("client_name_matcher"), &clientNameMatcher)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("client_selector"), &clientSelector)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !action.IsNull() && !action.IsUnknown() && !slices.Contains(secretPolicyRuleActions, action.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("action"),
+			"Invalid secret policy rule action",
+			fmt.Sprintf("The action attribute must be one of %s, got: %q", strings.Join(secretPolicyRuleActions, ", "), action.ValueString()),
+		)
+	}
+
+	matchers := 0
+	for _, matcher := range []attr.Value{clientName, clientNameMatcher, clientSelector} {
+		if !matcher.IsNull() {
+			matchers++
+		}
+	}
+	if matchers != 1 {
+		resp.Diagnostics.AddError(
+			"Invalid secret policy rule client matching",
+			"Exactly one of client_name, client_name_matcher, or client_selector must be set for a secret policy rule.",
+		)
+	}
+}
+
+// Implement the Create function for Resource interface.
+func (r *secretPolicyRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) { //nolint:gocritic // Provider interface passes CreateRequest by value.
+	tflog.Info(ctx, "Creating secret policy rule resource")
+	var model secretPolicyRuleResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "secret_policy_rule_name", model.Name.ValueString())
+	ctx = tflog.SetField(ctx, "secret_policy_rule_namespace", model.Namespace.ValueString())
+
+	tflog.Debug(ctx, "Creating Secret Policy Rule")
+	endpoint, err := secretManagementURL(r.url, model.Namespace.ValueString(), secretPolicyRuleKind)
+	if err == nil {
+		clientCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+		err = doAPIRequest(clientCtx, r.client, http.MethodPost, endpoint, model.toJSON(), nil)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating SecretPolicyRule",
+			"Could not create SecretPolicyRule, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	model.ID = types.StringValue(model.Namespace.ValueString() + "/" + model.Name.ValueString())
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Implement the Read function for Resource interface. The secret policy rule will be removed from state if it no longer
+// exists in F5XC.
+func (r *secretPolicyRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) { //nolint:gocritic // Provider interface passes ReadRequest by value.
+	tflog.Info(ctx, "Reading secret policy rule resource")
+	var model secretPolicyRuleResourceModel
+	diags := req.State.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "secret_policy_rule_name", model.Name.ValueString())
+	ctx = tflog.SetField(ctx, "secret_policy_rule_namespace", model.Namespace.ValueString())
+
+	tflog.Debug(ctx, "Fetching Secret Policy Rule")
+	var rule secretPolicyRuleJSON
+	endpoint, err := secretManagementURL(r.url, model.Namespace.ValueString(), secretPolicyRuleKind, model.Name.ValueString())
+	if err == nil {
+		clientCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+		err = doAPIRequest(clientCtx, r.client, http.MethodGet, endpoint, nil, &rule)
+	}
+	switch {
+	case errors.Is(err, errNotFound):
+		tflog.Warn(ctx, "Secret Policy Rule was not found, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	case err != nil:
+		resp.Diagnostics.AddError(
+			"Error retrieving SecretPolicyRule",
+			"Could not retrieve SecretPolicyRule, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	model.fromJSON(&rule)
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Implement the Update function for Resource interface.
+func (r *secretPolicyRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) { //nolint:gocritic // Provider interface passes UpdateRequest by value.
+	tflog.Info(ctx, "Updating secret policy rule resource")
+	var model secretPolicyRuleResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "secret_policy_rule_name", model.Name.ValueString())
+	ctx = tflog.SetField(ctx, "secret_policy_rule_namespace", model.Namespace.ValueString())
+
+	tflog.Debug(ctx, "Replacing Secret Policy Rule")
+	endpoint, err := secretManagementURL(r.url, model.Namespace.ValueString(), secretPolicyRuleKind, model.Name.ValueString())
+	if err == nil {
+		clientCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+		err = doAPIRequest(clientCtx, r.client, http.MethodPut, endpoint, model.toJSON(), nil)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating SecretPolicyRule",
+			"Could not update SecretPolicyRule, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	model.ID = types.StringValue(model.Namespace.ValueString() + "/" + model.Name.ValueString())
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Implement the Delete function for Resource interface.
+func (r *secretPolicyRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) { //nolint:gocritic // Provider interface passes DeleteRequest by value.
+	tflog.Info(ctx, "Deleting secret policy rule resource")
+	var model secretPolicyRuleResourceModel
+	diags := req.State.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "secret_policy_rule_name", model.Name.ValueString())
+	ctx = tflog.SetField(ctx, "secret_policy_rule_namespace", model.Namespace.ValueString())
+
+	tflog.Debug(ctx, "Deleting Secret Policy Rule")
+	endpoint, err := secretManagementURL(r.url, model.Namespace.ValueString(), secretPolicyRuleKind, model.Name.ValueString())
+	if err == nil {
+		clientCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+		err = doAPIRequest(clientCtx, r.client, http.MethodDelete, endpoint, nil, nil)
+	}
+	if err != nil && !errors.Is(err, errNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting SecretPolicyRule",
+			"Could not delete SecretPolicyRule, unexpected error: "+err.Error(),
+		)
+	}
+}
+
+// Implement the ImportState function for ResourceWithImportState interface. Secret policy rules are imported using an
+// identifier of the form namespace/name.
+func (r *secretPolicyRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	namespace, name, ok := strings.Cut(req.ID, "/")
+	if !ok || namespace == "" || name == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import identifier",
+			"Expected an import identifier of the form namespace/name, got: "+req.ID,
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("namespace"), namespace)...)
+}
+
+// Returns the F5XC API representation of the secret policy rule model.
+func (m *secretPolicyRuleResourceModel) toJSON() *secretPolicyRuleJSON {
+	rule := &secretPolicyRuleJSON{
+		Metadata: objectMetadataJSON{
+			Name:        m.Name.ValueString(),
+			Namespace:   m.Namespace.ValueString(),
+			Description: m.Description.ValueString(),
+		},
+		Spec: secretPolicyRuleSpecJSON{
+			Action:     m.Action.ValueString(),
+			ClientName: m.ClientName.ValueString(),
+		},
+	}
+	if m.ClientNameMatcher != nil {
+		rule.Spec.ClientNameMatcher = &clientNameMatcherJSON{
+			ExactValues: stringsFromValues(m.ClientNameMatcher.ExactValues),
+			RegexValues: stringsFromValues(m.ClientNameMatcher.RegexValues),
+		}
+	}
+	if m.ClientSelector != nil {
+		rule.Spec.ClientSelector = &clientSelectorJSON{
+			Expressions: stringsFromValues(m.ClientSelector.Expressions),
+		}
+	}
+	return rule
+}
+
+// Updates the secret policy rule model from the F5XC API representation.
+func (m *secretPolicyRuleResourceModel) fromJSON(rule *secretPolicyRuleJSON) {
+	m.Name = types.StringValue(rule.Metadata.Name)
+	m.Namespace = types.StringValue(rule.Metadata.Namespace)
+	m.ID = types.StringValue(rule.Metadata.Namespace + "/" + rule.Metadata.Name)
+	m.Description = optionalStringValue(rule.Metadata.Description)
+	m.Action = types.StringValue(rule.Spec.Action)
+	m.ClientName = optionalStringValue(rule.Spec.ClientName)
+	m.ClientNameMatcher = nil
+	if rule.Spec.ClientNameMatcher != nil {
+		m.ClientNameMatcher = &clientNameMatcherModel{
+			ExactValues: stringValues(rule.Spec.ClientNameMatcher.ExactValues),
+			RegexValues: stringValues(rule.Spec.ClientNameMatcher.RegexValues),
+		}
+	}
+	m.ClientSelector = nil
+	if rule.Spec.ClientSelector != nil {
+		m.ClientSelector = &clientSelectorModel{
+			Expressions: stringValues(rule.Spec.ClientSelector.Expressions),
+		}
+	}
+}
+
+// Returns a slice of strings from a slice of Terraform string values.
+func stringsFromValues(values []types.String) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		result = append(result, value.ValueString())
+	}
+	return result
+}
@@ -0,0 +1,57 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSecretPolicyRuleResource(t *testing.T) {
+	t.Parallel()
+	name := acctest.RandomWithPrefix("tf-acc")
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "f5xc_secret_policy_rule" "test" {
+	name = "` + name + `"
+	namespace = "shared"
+	action = "ALLOW"
+	client_name_matcher = {
+		regex_values = ["^ves-io-.*$"]
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("f5xc_secret_policy_rule.test", "id", "shared/"+name),
+					resource.TestCheckResourceAttr("f5xc_secret_policy_rule.test", "action", "ALLOW"),
+					resource.TestCheckResourceAttr("f5xc_secret_policy_rule.test", "client_name_matcher.regex_values.0", "^ves-io-.*$"),
+				),
+			},
+			{
+				ResourceName:      "f5xc_secret_policy_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: providerConfig + `
+resource "f5xc_secret_policy_rule" "test" {
+	name = "` + name + `"
+	namespace = "shared"
+	action = "DENY"
+	client_selector = {
+		expressions = ["site in (test)"]
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("f5xc_secret_policy_rule.test", "action", "DENY"),
+					resource.TestCheckNoResourceAttr("f5xc_secret_policy_rule.test", "client_name_matcher"),
+					resource.TestCheckResourceAttr("f5xc_secret_policy_rule.test", "client_selector.expressions.0", "site in (test)"),
+				),
+			},
+		},
+	})
+}
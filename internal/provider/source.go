@@ -0,0 +1,264 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// maxPlaintextSourceStderr is the number of bytes of standard error from a failed plaintext source command that will be
+// logged.
+const maxPlaintextSourceStderr = 1024
+
+var (
+	// errPlaintextSourceEnvNotSet is returned when the environment variable named by a plaintext source is not set.
+	errPlaintextSourceEnvNotSet = errors.New("environment variable is not set")
+	// errPlaintextSourceCommand is returned when the command of a plaintext source fails.
+	errPlaintextSourceCommand = errors.New("plaintext source command failed")
+	// errPlaintextSourceUnknown is returned when a plaintext source cannot be resolved because it has unknown values.
+	errPlaintextSourceUnknown = errors.New("plaintext source has unknown values")
+)
+
+// plaintextSourceModel describes a plaintext value that is read from an environment variable, a file, or the output of
+// a command when the secret is blindfolded, instead of from configuration.
+type plaintextSourceModel struct {
+	Env         types.String                 `tfsdk:"env"`
+	File        types.String                 `tfsdk:"file"`
+	Command     *plaintextSourceCommandModel `tfsdk:"command"`
+	TrimNewline types.Bool                   `tfsdk:"trim_newline"`
+}
+
+// plaintextSourceCommandModel describes a command whose standard output is the plaintext value.
+type plaintextSourceCommandModel struct {
+	Program types.String `tfsdk:"program"`
+	Args    types.List   `tfsdk:"args"`
+	Env     types.Map    `tfsdk:"env"`
+	Timeout types.String `tfsdk:"timeout"`
+}
+
+// Returns the schema of a source attribute that can be used in place of the named plaintext attribute.
+func plaintextSourceSchema(alternative string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Reads the plaintext data that will be blindfolded from an environment variable, a " +
+			"file, or the output of a command, as an alternative to `" + alternative + "`. Exactly one of `env`, " +
+			"`file`, or `command` must be provided. The source is read during plan to detect changes, and the value " +
+			"is never stored in Terraform plan or state. If the source has values that are unknown until apply, the " +
+			"secret will be blindfolded again.",
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"env": schema.StringAttribute{
+				Description: "The name of an environment variable of the Terraform process that contains the plaintext.",
+				Optional:    true,
+			},
+			"file": schema.StringAttribute{
+				Description: "The path of a file that contains the plaintext.",
+				Optional:    true,
+			},
+			"command": schema.SingleNestedAttribute{
+				MarkdownDescription: "A command that will write the plaintext to standard output. Standard error is " +
+					"not included in diagnostics, and is only logged at debug level.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"program": schema.StringAttribute{
+						Description: "The name or path of the program to execute.",
+						Required:    true,
+					},
+					"args": schema.ListAttribute{
+						Description: "The arguments to pass to the program.",
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+					"env": schema.MapAttribute{
+						MarkdownDescription: "Additional environment variables to set for the program, as a " +
+							"write-only value that is never stored in Terraform plan or state. Requires Terraform " +
+							"1.11 or later.",
+						ElementType: types.StringType,
+						Optional:    true,
+						Sensitive:   true,
+						WriteOnly:   true,
+					},
+					"timeout": schema.StringAttribute{
+						MarkdownDescription: "The maximum duration, such as `30s`, that the program may run. If " +
+							"unspecified, the provider `timeout` will be used.",
+						Optional: true,
+					},
+				},
+			},
+			"trim_newline": schema.BoolAttribute{
+				Description: "If true, a single trailing newline will be removed from the plaintext.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// Returns the attribute types of the source attribute.
+func plaintextSourceAttrTypes() map[string]attr.Type {
+	return plaintextSourceSchema("plaintext").GetType().(types.ObjectType).AttrTypes //nolint:forcetypeassert // SingleNestedAttribute is always an object type.
+}
+
+// attributeGetter is implemented by config, plan, and state.
+type attributeGetter interface {
+	GetAttribute(ctx context.Context, p path.Path, target any) diag.Diagnostics
+}
+
+// Returns the plaintext source at path from the config, plan or state, or nil if the source is null or has any unknown
+// values.
+func getPlaintextSource(ctx context.Context, getter attributeGetter, p path.Path) (*plaintextSourceModel, diag.Diagnostics) {
+	var obj types.Object
+	diags := getter.GetAttribute(ctx, p, &obj)
+	if diags.HasError() || obj.IsNull() || hasUnknownValue(obj) {
+		return nil, diags
+	}
+	var source plaintextSourceModel
+	diags.Append(obj.As(ctx, &source, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	return &source, diags
+}
+
+// Returns true if the value, or any value nested in an object, list, or map, is unknown.
+func hasUnknownValue(value attr.Value) bool {
+	if value.IsUnknown() {
+		return true
+	}
+	var nested []attr.Value
+	switch v := value.(type) {
+	case types.Object:
+		for _, attrValue := range v.Attributes() {
+			nested = append(nested, attrValue)
+		}
+	case types.List:
+		nested = v.Elements()
+	case types.Map:
+		for _, element := range v.Elements() {
+			nested = append(nested, element)
+		}
+	}
+	return slices.ContainsFunc(nested, hasUnknownValue)
+}
+
+// Validates the source attribute of the config; exactly one of env, file, or command must be set and the command
+// timeout must be a valid duration.
+func validatePlaintextSource(ctx context.Context, config *tfsdk.Config) diag.Diagnostics {
+	source, diags := getPlaintextSource(ctx, config, path.Root("source"))
+	if diags.HasError() || source == nil {
+		return diags
+	}
+	count := 0
+	for _, set := range []bool{!source.Env.IsNull(), !source.File.IsNull(), source.Command != nil} {
+		if set {
+			count++
+		}
+	}
+	if count != 1 {
+		diags.AddAttributeError(
+			path.Root("source"),
+			"Invalid source",
+			"Exactly one of env, file, or command must be set for a plaintext source.",
+		)
+		return diags
+	}
+	if source.Command == nil || source.Command.Timeout.IsNull() || source.Command.Timeout.IsUnknown() {
+		return diags
+	}
+	if timeout, err := time.ParseDuration(source.Command.Timeout.ValueString()); err != nil || timeout <= 0 {
+		diags.AddAttributeError(
+			path.Root("source").AtName("command").AtName("timeout"),
+			"Invalid timeout",
+			"The command timeout must be a positive duration, got: "+source.Command.Timeout.ValueString(),
+		)
+	}
+	return diags
+}
+
+// Returns the plaintext value from the source. Commands are executed with defaultTimeout unless the source has its own
+// timeout.
+func (s *plaintextSourceModel) read(ctx context.Context, defaultTimeout time.Duration) ([]byte, error) {
+	var value []byte
+	switch {
+	case s.Env.IsUnknown() || s.File.IsUnknown():
+		return nil, errPlaintextSourceUnknown
+	case !s.Env.IsNull():
+		env, ok := os.LookupEnv(s.Env.ValueString())
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", errPlaintextSourceEnvNotSet, s.Env.ValueString())
+		}
+		value = []byte(env)
+	case !s.File.IsNull():
+		data, err := os.ReadFile(s.File.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read plaintext source file: %w", err)
+		}
+		value = data
+	case s.Command != nil:
+		output, err := s.Command.run(ctx, defaultTimeout)
+		if err != nil {
+			return nil, err
+		}
+		value = output
+	}
+	if trimmed, ok := bytes.CutSuffix(value, []byte("\n")); ok && s.TrimNewline.ValueBool() {
+		value = bytes.TrimSuffix(trimmed, []byte("\r"))
+	}
+	return value, nil
+}
+
+// Executes the command and returns its standard output.
+func (c *plaintextSourceCommandModel) run(ctx context.Context, defaultTimeout time.Duration) ([]byte, error) {
+	if c.Program.IsUnknown() || c.Args.IsUnknown() || c.Env.IsUnknown() || c.Timeout.IsUnknown() {
+		return nil, errPlaintextSourceUnknown
+	}
+	timeout := defaultTimeout
+	if !c.Timeout.IsNull() {
+		parsed, err := time.ParseDuration(c.Timeout.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse command timeout: %w", err)
+		}
+		timeout = parsed
+	}
+	var args []string
+	if diags := c.Args.ElementsAs(ctx, &args, false); diags.HasError() {
+		return nil, errPlaintextSourceUnknown
+	}
+	var env map[string]string
+	if diags := c.Env.ElementsAs(ctx, &env, false); diags.HasError() {
+		return nil, errPlaintextSourceUnknown
+	}
+	cmdCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	cmd := exec.CommandContext(cmdCtx, c.Program.ValueString(), args...)
+	cmd.Env = os.Environ()
+	for key, value := range env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		clear(output)
+		// Standard error may echo the plaintext or other secrets, so it is kept out of the error.
+		detail := bytes.TrimSpace(stderr.Bytes())
+		if len(detail) > maxPlaintextSourceStderr {
+			detail = detail[:maxPlaintextSourceStderr]
+		}
+		tflog.Debug(ctx, "Plaintext source command failed", map[string]any{"program": c.Program.ValueString(), "stderr": string(detail)})
+		return nil, fmt.Errorf("%w: %s: %w", errPlaintextSourceCommand, c.Program.ValueString(), err)
+	}
+	return output, nil
+}
@@ -0,0 +1,294 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testPlaintextSourceEnv = "F5XC_TEST_PLAINTEXT_SOURCE"
+
+// Returns a plaintext source command that runs program with args.
+func testPlaintextSourceCommand(program string, args ...string) *plaintextSourceCommandModel {
+	elements := make([]attr.Value, 0, len(args))
+	for _, arg := range args {
+		elements = append(elements, types.StringValue(arg))
+	}
+	return &plaintextSourceCommandModel{
+		Program: types.StringValue(program),
+		Args:    types.ListValueMust(types.StringType, elements),
+		Env:     types.MapNull(types.StringType),
+		Timeout: types.StringNull(),
+	}
+}
+
+func TestPlaintextSourceRead(t *testing.T) {
+	t.Setenv(testPlaintextSourceEnv, "This is a test\r\n")
+	plaintextFile := filepath.Join(t.TempDir(), "plaintext")
+	if err := os.WriteFile(plaintextFile, []byte("This is a test\n\n"), 0o600); err != nil {
+		t.Fatalf("failed to write plaintext file: %v", err)
+	}
+	unknownEnv := testPlaintextSourceCommand("env")
+	unknownEnv.Env = types.MapUnknown(types.StringType)
+	withEnv := testPlaintextSourceCommand("sh", "-c", `printf '%s' "$`+testPlaintextSourceEnv+`_CHILD"`)
+	withEnv.Env = types.MapValueMust(types.StringType, map[string]attr.Value{
+		testPlaintextSourceEnv + "_CHILD": types.StringValue("This is a test"),
+	})
+	timeout := testPlaintextSourceCommand("sleep", "5")
+	timeout.Timeout = types.StringValue("10ms")
+	tests := []struct {
+		name          string
+		source        plaintextSourceModel
+		expected      string
+		expectedError error
+	}{
+		{
+			name:     "env",
+			source:   plaintextSourceModel{Env: types.StringValue(testPlaintextSourceEnv), File: types.StringNull()},
+			expected: "This is a test\r\n",
+		},
+		{
+			name: "env trim newline",
+			source: plaintextSourceModel{
+				Env:         types.StringValue(testPlaintextSourceEnv),
+				File:        types.StringNull(),
+				TrimNewline: types.BoolValue(true),
+			},
+			expected: "This is a test",
+		},
+		{
+			name:          "env not set",
+			source:        plaintextSourceModel{Env: types.StringValue(testPlaintextSourceEnv + "_UNSET"), File: types.StringNull()},
+			expectedError: errPlaintextSourceEnvNotSet,
+		},
+		{
+			name:          "env unknown",
+			source:        plaintextSourceModel{Env: types.StringUnknown(), File: types.StringNull()},
+			expectedError: errPlaintextSourceUnknown,
+		},
+		{
+			name:     "file",
+			source:   plaintextSourceModel{Env: types.StringNull(), File: types.StringValue(plaintextFile)},
+			expected: "This is a test\n\n",
+		},
+		{
+			name: "file trims a single newline",
+			source: plaintextSourceModel{
+				Env:         types.StringNull(),
+				File:        types.StringValue(plaintextFile),
+				TrimNewline: types.BoolValue(true),
+			},
+			expected: "This is a test\n",
+		},
+		{
+			name:          "file unknown",
+			source:        plaintextSourceModel{Env: types.StringNull(), File: types.StringUnknown()},
+			expectedError: errPlaintextSourceUnknown,
+		},
+		{
+			name: "command",
+			source: plaintextSourceModel{
+				Env:     types.StringNull(),
+				File:    types.StringNull(),
+				Command: testPlaintextSourceCommand("cat", plaintextFile),
+			},
+			expected: "This is a test\n\n",
+		},
+		{
+			name: "command env",
+			source: plaintextSourceModel{
+				Env:     types.StringNull(),
+				File:    types.StringNull(),
+				Command: withEnv,
+			},
+			expected: "This is a test",
+		},
+		{
+			name: "command fails",
+			source: plaintextSourceModel{
+				Env:     types.StringNull(),
+				File:    types.StringNull(),
+				Command: testPlaintextSourceCommand("cat", plaintextFile+".missing"),
+			},
+			expectedError: errPlaintextSourceCommand,
+		},
+		{
+			name: "command timeout",
+			source: plaintextSourceModel{
+				Env:     types.StringNull(),
+				File:    types.StringNull(),
+				Command: timeout,
+			},
+			expectedError: errPlaintextSourceCommand,
+		},
+		{
+			name: "command unknown env",
+			source: plaintextSourceModel{
+				Env:     types.StringNull(),
+				File:    types.StringNull(),
+				Command: unknownEnv,
+			},
+			expectedError: errPlaintextSourceUnknown,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := test.source.read(context.Background(), 10*time.Second)
+			switch {
+			case test.expectedError != nil && !errors.Is(err, test.expectedError):
+				t.Errorf("expected error %v, got %v", test.expectedError, err)
+			case test.expectedError == nil && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case string(result) != test.expected:
+				t.Errorf("expected %q, got %q", test.expected, result)
+			}
+		})
+	}
+}
+
+// A failed command must not include standard error in its error, as it may echo the plaintext.
+func TestPlaintextSourceCommandStderr(t *testing.T) {
+	t.Parallel()
+	command := testPlaintextSourceCommand("sh", "-c", "echo 'This is a secret' >&2; exit 1")
+	_, err := command.run(context.Background(), 10*time.Second)
+	if !errors.Is(err, errPlaintextSourceCommand) {
+		t.Fatalf("expected error %v, got %v", errPlaintextSourceCommand, err)
+	}
+	if strings.Contains(err.Error(), "This is a secret") {
+		t.Errorf("expected error to omit standard error, got %v", err)
+	}
+}
+
+func TestHasUnknownValue(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		value    attr.Value
+		expected bool
+	}{
+		{name: "null", value: types.StringNull()},
+		{name: "known", value: types.StringValue("test")},
+		{name: "unknown", value: types.StringUnknown(), expected: true},
+		{
+			name:  "known list",
+			value: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("test")}),
+		},
+		{
+			name:     "list with unknown element",
+			value:    types.ListValueMust(types.StringType, []attr.Value{types.StringUnknown()}),
+			expected: true,
+		},
+		{
+			name:     "map with unknown element",
+			value:    types.MapValueMust(types.StringType, map[string]attr.Value{"key": types.StringUnknown()}),
+			expected: true,
+		},
+		{
+			name: "object with unknown nested object",
+			value: types.ObjectValueMust(
+				map[string]attr.Type{"command": types.ObjectType{AttrTypes: map[string]attr.Type{"program": types.StringType}}},
+				map[string]attr.Value{"command": types.ObjectUnknown(map[string]attr.Type{"program": types.StringType})},
+			),
+			expected: true,
+		},
+		{
+			name: "object with unknown nested list element",
+			value: types.ObjectValueMust(
+				map[string]attr.Type{"args": types.ListType{ElemType: types.StringType}},
+				map[string]attr.Value{"args": types.ListValueMust(types.StringType, []attr.Value{types.StringUnknown()})},
+			),
+			expected: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			if result := hasUnknownValue(test.value); result != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, result)
+			}
+		})
+	}
+}
+
+// testAttributeGetter returns a fixed object for any path.
+type testAttributeGetter struct {
+	value types.Object
+}
+
+func (g testAttributeGetter) GetAttribute(_ context.Context, _ path.Path, target any) diag.Diagnostics {
+	*target.(*types.Object) = g.value //nolint:forcetypeassert // Only used to get plaintext sources.
+	return nil
+}
+
+func TestGetPlaintextSource(t *testing.T) {
+	t.Parallel()
+	attrTypes := plaintextSourceAttrTypes()
+	commandTypes := attrTypes["command"].(types.ObjectType).AttrTypes //nolint:forcetypeassert // command is a nested object.
+	command := func(program attr.Value) attr.Value {
+		return types.ObjectValueMust(commandTypes, map[string]attr.Value{
+			"program": program,
+			"args":    types.ListNull(types.StringType),
+			"env":     types.MapNull(types.StringType),
+			"timeout": types.StringNull(),
+		})
+	}
+	source := func(env attr.Value, cmd attr.Value) types.Object {
+		return types.ObjectValueMust(attrTypes, map[string]attr.Value{
+			"env":          env,
+			"file":         types.StringNull(),
+			"command":      cmd,
+			"trim_newline": types.BoolNull(),
+		})
+	}
+	tests := []struct {
+		name            string
+		source          types.Object
+		expectedNil     bool
+		expectedCommand bool
+	}{
+		{name: "null", source: types.ObjectNull(attrTypes), expectedNil: true},
+		{name: "unknown", source: types.ObjectUnknown(attrTypes), expectedNil: true},
+		{name: "env", source: source(types.StringValue(testPlaintextSourceEnv), types.ObjectNull(commandTypes))},
+		{name: "unknown env", source: source(types.StringUnknown(), types.ObjectNull(commandTypes)), expectedNil: true},
+		{
+			name:            "command",
+			source:          source(types.StringNull(), command(types.StringValue("cat"))),
+			expectedCommand: true,
+		},
+		{
+			name:        "unknown command",
+			source:      source(types.StringNull(), types.ObjectUnknown(commandTypes)),
+			expectedNil: true,
+		},
+		{
+			name:        "unknown command program",
+			source:      source(types.StringNull(), command(types.StringUnknown())),
+			expectedNil: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			result, diags := getPlaintextSource(context.Background(), testAttributeGetter{value: test.source}, path.Root("source"))
+			switch {
+			case diags.HasError():
+				t.Errorf("unexpected error: %v", diags)
+			case test.expectedNil && result != nil:
+				t.Errorf("expected nil source, got %+v", result)
+			case !test.expectedNil && result == nil:
+				t.Error("expected source, got nil")
+			case result != nil && (result.Command != nil) != test.expectedCommand:
+				t.Errorf("expected command to be set to be %t, got %+v", test.expectedCommand, result.Command)
+			}
+		})
+	}
+}
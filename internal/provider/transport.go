@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/time/rate"
+)
+
+// The maximum number of bytes of a response body that will be drained before retrying, so that the connection can be
+// reused.
+const maxDrainLength = 4096
+
+// retryTransport is an http.RoundTripper that limits the rate of requests made to the F5XC API, and retries idempotent
+// requests that fail with a transport error or a 429, 502, 503 or 504 status using exponential backoff.
+type retryTransport struct {
+	next       http.RoundTripper
+	limiter    *rate.Limiter
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+// Returns a new retryTransport that wraps next, which may be nil to use http.DefaultTransport. A requestsPerSecond of
+// zero disables rate limiting.
+func newRetryTransport(next http.RoundTripper, maxRetries int, minBackoff, maxBackoff time.Duration, requestsPerSecond float64) *retryTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	var limiter *rate.Limiter
+	if requestsPerSecond > 0 {
+		limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), max(1, int(math.Ceil(requestsPerSecond))))
+	}
+	return &retryTransport{
+		next:       next,
+		limiter:    limiter,
+		maxRetries: maxRetries,
+		minBackoff: minBackoff,
+		maxBackoff: maxBackoff,
+	}
+}
+
+// Implement the RoundTrip function for http.RoundTripper interface.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		if t.limiter != nil {
+			if err := t.limiter.Wait(ctx); err != nil {
+				return nil, fmt.Errorf("failed waiting for rate limiter: %w", err)
+			}
+		}
+		attemptReq := req
+		if attempt > 0 && req.Body != nil && req.Body != http.NoBody {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to rewind request body: %w", err)
+			}
+			attemptReq = req.Clone(ctx)
+			attemptReq.Body = body
+		}
+		resp, err := t.next.RoundTrip(attemptReq)
+		if attempt >= t.maxRetries || !isRetryableRequest(req) || !isRetryableResponse(ctx, resp, err) {
+			return resp, err //nolint:wrapcheck // Errors from the wrapped transport are returned unchanged.
+		}
+		delay := t.backoff(attempt, resp)
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+			return resp, err //nolint:wrapcheck // Errors from the wrapped transport are returned unchanged.
+		}
+		fields := map[string]any{
+			"method":  req.Method,
+			"url":     req.URL.Redacted(),
+			"attempt": attempt + 1,
+			"delay":   delay.String(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["status"] = resp.Status
+			drainResponse(ctx, resp)
+		}
+		tflog.Warn(ctx, "Retrying F5XC API request", fields)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("request cancelled while waiting to retry: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// Returns the delay before retrying a request that failed on attempt, which is the Retry-After value of resp if it is
+// present, otherwise an exponential backoff between minBackoff and maxBackoff with jitter.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if delay, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return delay
+		}
+	}
+	delay := t.minBackoff
+	for i := 0; i < attempt && delay < t.maxBackoff; i++ {
+		delay *= 2
+	}
+	delay = min(delay, t.maxBackoff)
+	if delay <= t.minBackoff || delay/2 <= 0 {
+		return delay
+	}
+	// Use the upper half of the window so that concurrent clients spread out their retries.
+	return delay/2 + rand.N(delay/2) //nolint:gosec // Jitter does not need a secure random source.
+}
+
+// Returns the delay requested by a Retry-After header value, which may be a number of seconds or an HTTP date.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(0, seconds)) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(0, time.Until(at)), true
+	}
+	return 0, false
+}
+
+// Returns true if the request is idempotent and can be sent again; requests with a body must be able to rewind it.
+func isRetryableRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+	default:
+		return false
+	}
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// Returns true if the response or error is transient and the request should be retried.
+func isRetryableResponse(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// Reads and closes a limited amount of the response body so that the underlying connection can be reused.
+func drainResponse(ctx context.Context, resp *http.Response) {
+	if _, err := io.CopyN(io.Discard, resp.Body, maxDrainLength); err != nil && !errors.Is(err, io.EOF) {
+		tflog.Debug(ctx, "Failed to drain API response body", map[string]any{"error": err.Error()})
+	}
+	if err := resp.Body.Close(); err != nil {
+		tflog.Warn(ctx, "Failed to close API response body", map[string]any{"error": err.Error()})
+	}
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		method        string
+		statuses      []int
+		retryAfter    string
+		expectedCalls int32
+		expectedCode  int
+	}{
+		{
+			name:          "success",
+			method:        http.MethodGet,
+			statuses:      []int{http.StatusOK},
+			expectedCalls: 1,
+			expectedCode:  http.StatusOK,
+		},
+		{
+			name:          "retry-unavailable",
+			method:        http.MethodGet,
+			statuses:      []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			expectedCalls: 3,
+			expectedCode:  http.StatusOK,
+		},
+		{
+			name:          "retry-after",
+			method:        http.MethodPut,
+			statuses:      []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter:    "0",
+			expectedCalls: 2,
+			expectedCode:  http.StatusOK,
+		},
+		{
+			name:          "exhausted",
+			method:        http.MethodGet,
+			statuses:      []int{http.StatusServiceUnavailable},
+			expectedCalls: 3,
+			expectedCode:  http.StatusServiceUnavailable,
+		},
+		{
+			name:          "not-idempotent",
+			method:        http.MethodPost,
+			statuses:      []int{http.StatusServiceUnavailable, http.StatusOK},
+			expectedCalls: 1,
+			expectedCode:  http.StatusServiceUnavailable,
+		},
+		{
+			name:          "not-retryable",
+			method:        http.MethodGet,
+			statuses:      []int{http.StatusInternalServerError, http.StatusOK},
+			expectedCalls: 1,
+			expectedCode:  http.StatusInternalServerError,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				call := int(calls.Add(1)) - 1
+				if r.Method == http.MethodPut {
+					body, err := io.ReadAll(r.Body)
+					if err != nil || string(body) != "payload" {
+						t.Errorf("expected request body to be resent on attempt %d, got %q: %v", call, string(body), err)
+					}
+				}
+				if test.retryAfter != "" {
+					w.Header().Set("Retry-After", test.retryAfter)
+				}
+				w.WriteHeader(test.statuses[min(call, len(test.statuses)-1)])
+			}))
+			t.Cleanup(server.Close)
+			client := &http.Client{
+				Transport: newRetryTransport(nil, 2, time.Millisecond, 10*time.Millisecond, 0),
+			}
+			req, err := http.NewRequestWithContext(t.Context(), test.method, server.URL, strings.NewReader("payload"))
+			if err != nil {
+				t.Fatalf("unexpected error creating request: %v", err)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := resp.Body.Close(); err != nil {
+				t.Errorf("unexpected error closing response body: %v", err)
+			}
+			if resp.StatusCode != test.expectedCode {
+				t.Errorf("expected status %d, got %d", test.expectedCode, resp.StatusCode)
+			}
+			if got := calls.Load(); got != test.expectedCalls {
+				t.Errorf("expected %d calls, got %d", test.expectedCalls, got)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+	if delay, ok := retryAfter("5"); !ok || delay != 5*time.Second {
+		t.Errorf("expected 5s, got %s, %t", delay, ok)
+	}
+	if delay, ok := retryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)); !ok || delay != 0 {
+		t.Errorf("expected 0s for a date in the past, got %s, %t", delay, ok)
+	}
+	if _, ok := retryAfter("soon"); ok {
+		t.Error("expected an invalid value to be ignored")
+	}
+}